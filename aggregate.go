@@ -0,0 +1,86 @@
+package log
+
+import (
+	"fmt"
+	"time"
+)
+
+// Aggregate counts an occurrence of key, see Logger.Aggregate.
+func Aggregate(key string, d time.Duration) {
+	std.Aggregate(key, d)
+}
+
+// SetAggregateLevel sets the level of the messages logged by Aggregate.
+func SetAggregateLevel(level Severity) {
+	std.SetAggregateLevel(level)
+}
+
+// Aggregate counts an occurrence of key instead of logging it. The first
+// occurrence starts a window of duration d, at the end of which a single
+// "key occurred N times in d" message is logged and the count restarts.
+// d is only used when starting a window. Close logs the pending counts.
+func (l *Logger) Aggregate(key string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if a, ok := l.aggs[key]; ok {
+		a.n++
+		return
+	}
+	if l.aggs == nil {
+		l.aggs = map[string]*aggregate{}
+	}
+	a := &aggregate{n: 1, d: d}
+	a.t = time.AfterFunc(d, func() {
+		l.mu.Lock()
+		if l.aggs[key] != a {
+			l.mu.Unlock()
+			return
+		}
+		delete(l.aggs, key)
+		level := l.aggLevel
+		l.mu.Unlock()
+
+		l.logAggregate(level, key, a)
+	})
+	l.aggs[key] = a
+}
+
+// SetAggregateLevel sets the level of the messages logged by Aggregate,
+// LevelInfo by default.
+func (l *Logger) SetAggregateLevel(level Severity) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.aggLevel = level
+}
+
+// flushAggregates logs the counts of the windows still open.
+func (l *Logger) flushAggregates() {
+	l.mu.Lock()
+	aggs := l.aggs
+	l.aggs = nil
+	level := l.aggLevel
+	l.mu.Unlock()
+
+	for key, a := range aggs {
+		if a.t.Stop() {
+			l.logAggregate(level, key, a)
+		}
+	}
+}
+
+// logAggregate logs the count of a closed window.
+func (l *Logger) logAggregate(level Severity, key string, a *aggregate) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.output(level, fmt.Sprintf("%s occurred %d times in %v", key, a.n, a.d))
+}
+
+// aggregate is the count of an Aggregate key over an open window.
+type aggregate struct {
+	n int
+	d time.Duration
+	t *time.Timer
+}
@@ -0,0 +1,73 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAggregate(t *testing.T) {
+	w := new(syncBuffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetAggregateLevel(LevelWarning)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Aggregate("timeout", 20*time.Millisecond)
+		}()
+	}
+	wg.Wait()
+	if w.String() != "" {
+		t.Fatalf("aggregate logged before the window closed: %q", w.String())
+	}
+
+	waitFor(t, func() bool { return w.String() != "" })
+	pattern := ts + lp[1] + "timeout occurred 10 times in 20ms\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Fatalf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+
+	l.Aggregate("timeout", time.Millisecond)
+	waitFor(t, func() bool { return strings.Count(w.String(), "\n") == 2 })
+	if !strings.HasSuffix(w.String(), "timeout occurred 1 times in 1ms\n") {
+		t.Errorf("count not restarted: %q", w.String())
+	}
+}
+
+func TestAggregateClose(t *testing.T) {
+	w := new(syncBuffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+
+	l.Aggregate("a", time.Hour)
+	l.Aggregate("a", time.Hour)
+	l.Aggregate("b", time.Hour)
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := w.String()
+	for _, want := range []string{lp[0] + "a occurred 2 times in 1h0m0s\n", lp[0] + "b occurred 1 times in 1h0m0s\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+}
+
+func TestAggregateLevel(t *testing.T) {
+	w := new(syncBuffer)
+	l := New(LevelWarning)
+	l.SetWriter(w)
+
+	l.Aggregate("a", time.Hour)
+	l.Close()
+	if w.String() != "" {
+		t.Errorf("aggregate logged below level: %q", w.String())
+	}
+}
@@ -0,0 +1,157 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// SetAsync enables asynchronous output on the standard logger,
+// see Logger.SetAsync.
+func SetAsync(bufferSize int) {
+	std.SetAsync(bufferSize)
+}
+
+// SetAsync makes the logger hand its lines to a background goroutine writing
+// them to the writer, so that slow writers do not hold up the callers.
+// Up to bufferSize lines are queued; when the queue is full, logging blocks
+// until there is room again, so no line is lost. Lines are written in the
+// order they were logged, formatted and timestamped when logged.
+// Flush waits for the queued lines to be written, Close also stops the
+// goroutine. A bufferSize less than or equal to zero restores synchronous
+// output (default), after writing the queued lines.
+// Only the main writer is asynchronous, not the error writer.
+func (l *Logger) SetAsync(bufferSize int) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.stopAsync()
+	if bufferSize <= 0 {
+		return
+	}
+	a := newAsyncWriter(l.writer(), bufferSize)
+	l.async = a
+	if l.pause != nil {
+		l.pause.w = a
+		return
+	}
+	l.out.SetOutput(a)
+}
+
+// stopAsync writes the queued lines and restores synchronous output, if
+// asynchronous. It requires l.omu to be held.
+func (l *Logger) stopAsync() {
+	a := l.async
+	if a == nil {
+		return
+	}
+	a.stop()
+	l.async = nil
+	if l.pause != nil {
+		l.pause.w = a.target()
+		return
+	}
+	l.out.SetOutput(a.target())
+}
+
+// asyncWriter is a writer queuing its writes for a goroutine writing them
+// to w.
+type asyncWriter struct {
+	q      chan asyncItem
+	exited chan struct{}
+
+	smu     sync.RWMutex // guards stopped, held for reading while queuing
+	stopped bool
+
+	mu sync.Mutex // guards w
+	w  io.Writer
+}
+
+// asyncItem is a queued write, or a flush request if done is not nil.
+type asyncItem struct {
+	p    []byte
+	done chan struct{}
+}
+
+// newAsyncWriter returns a writer queuing up to size writes for w.
+func newAsyncWriter(w io.Writer, size int) *asyncWriter {
+	a := &asyncWriter{
+		q:      make(chan asyncItem, size),
+		exited: make(chan struct{}),
+		w:      w,
+	}
+	go a.loop()
+	return a
+}
+
+// Write queues a copy of p, or writes it directly once stopped.
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	a.smu.RLock()
+	defer a.smu.RUnlock()
+
+	if a.stopped {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return writeSink(a.w, p)
+	}
+	a.q <- asyncItem{p: append([]byte(nil), p...)}
+	return len(p), nil
+}
+
+// drain waits for the queued writes to be done.
+func (a *asyncWriter) drain() {
+	a.smu.RLock()
+	defer a.smu.RUnlock()
+
+	if a.stopped {
+		return
+	}
+	done := make(chan struct{})
+	a.q <- asyncItem{done: done}
+	<-done
+}
+
+// stop writes the queued writes and stops the goroutine.
+func (a *asyncWriter) stop() {
+	a.smu.Lock()
+	defer a.smu.Unlock()
+
+	if !a.stopped {
+		a.stopped = true
+		close(a.q)
+		<-a.exited
+	}
+}
+
+// target returns the writer the writes go to.
+func (a *asyncWriter) target() io.Writer {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.w
+}
+
+// setTarget makes the following writes go to w, once the queued ones are
+// written to the previous writer.
+func (a *asyncWriter) setTarget(w io.Writer) {
+	a.drain()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.w = w
+}
+
+// loop writes the queued writes until the queue is closed.
+func (a *asyncWriter) loop() {
+	defer close(a.exited)
+
+	for it := range a.q {
+		if it.done != nil {
+			close(it.done)
+			continue
+		}
+		a.mu.Lock()
+		writeSink(a.w, it.p) // #nosec
+		a.mu.Unlock()
+	}
+}
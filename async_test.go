@@ -0,0 +1,123 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// slowWriter is a writer safe for concurrent use, blocking writes until
+// released.
+type slowWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	release chan struct{}
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *slowWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsync(t *testing.T) {
+	const n = 100
+	tt := []struct {
+		name string
+		size int
+		done func(l *Logger) error
+	}{
+		{"flush", 10, (*Logger).Flush},
+		{"close", 10, (*Logger).Close},
+		{"disable", 1, func(l *Logger) error { l.SetAsync(0); return nil }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &slowWriter{release: make(chan struct{})}
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetAsync(tc.size)
+			if l.Writer() != w {
+				t.Error("Writer: want the logger writer, got the queue")
+			}
+
+			l.Info("queued")
+			if got := w.String(); got != "" {
+				t.Fatalf("want the line queued, got %q", got)
+			}
+			close(w.release)
+			for i := 0; i < n; i++ {
+				l.Infof("line %d", i)
+			}
+			if err := tc.done(l); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+			if len(lines) != n+1 {
+				t.Fatalf("want %d lines, got %d", n+1, len(lines))
+			}
+			for i, line := range lines {
+				want := "queued"
+				if i > 0 {
+					want = fmt.Sprintf("line %d", i-1)
+				}
+				pattern := ts + regexp.QuoteMeta(lp[0]+want) + "$"
+				if !regexp.MustCompile(pattern).MatchString(line) {
+					t.Fatalf("mismatch! Pattern %q, got %q", pattern, line)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncSetWriter(t *testing.T) {
+	w1, w2 := new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w1)
+	l.SetAsync(10)
+	l.Info("one")
+	l.SetWriter(w2)
+	l.Info("two")
+	l.SetAsync(0)
+	l.Info("three")
+
+	if !strings.HasSuffix(w1.String(), lp[0]+"one\n") || strings.Count(w1.String(), "\n") != 1 {
+		t.Errorf("first writer: want one, got %q", w1.String())
+	}
+	if strings.Count(w2.String(), "\n") != 2 || !strings.Contains(w2.String(), lp[0]+"two\n") {
+		t.Errorf("second writer: want two and three, got %q", w2.String())
+	}
+	if l.Writer() != w2 {
+		t.Error("Writer after SetAsync(0): want the logger writer")
+	}
+}
+
+func TestAsyncPause(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetAsync(10)
+	resume := l.Pause()
+	l.Info("one")
+	resume()
+	l.Info("two")
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Count(w.String(), "\n"); got != 2 {
+		t.Errorf("want 2 lines, got %q", w.String())
+	}
+}
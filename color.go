@@ -0,0 +1,66 @@
+package log
+
+import (
+	"io"
+	"os"
+)
+
+// ColorMode selects whether level labels are colored, see SetColor.
+type ColorMode int
+
+// Available color modes.
+const (
+	ColorNever  ColorMode = iota // Plain labels (default)
+	ColorAuto                    // Colored labels when writing to a terminal
+	ColorAlways                  // Colored labels
+)
+
+// colors are the ANSI escape sequences coloring each level label.
+var colors = map[Severity]string{
+	LevelTrace:   "\x1b[90m",
+	LevelDebug:   "\x1b[36m",
+	LevelInfo:    "\x1b[32m",
+	LevelWarning: "\x1b[33m",
+	LevelError:   "\x1b[31m",
+}
+
+// colorReset is the ANSI escape sequence ending a colored label.
+const colorReset = "\x1b[0m"
+
+// SetColor sets the color mode of the standard logger, see Logger.SetColor.
+func SetColor(mode ColorMode) {
+	std.SetColor(mode)
+}
+
+// SetColor sets whether the level labels of the default output are colored,
+// e.g. WARN in yellow and ERROR in red. With ColorAuto they are only colored
+// when the writer is a terminal (see IsTerminal), never when output is
+// redirected to a file or a pipe. A custom Formatter is not affected.
+func (l *Logger) SetColor(mode ColorMode) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.color = mode
+}
+
+// colorize returns the level label to write to w, colored according to the
+// color mode. It requires l.omu to be held.
+func (l *Logger) colorize(level Severity, w io.Writer) string {
+	switch l.color {
+	case ColorNever:
+		return l.prefix(level)
+	case ColorAuto:
+		f, ok := w.(*os.File)
+		if !ok {
+			return l.prefix(level)
+		}
+		if f != l.ttyFile {
+			// Cache the last answer, writers seldom change.
+			l.ttyFile, l.tty = f, isTerminal(f)
+		}
+		if !l.tty {
+			return l.prefix(level)
+		}
+	}
+	return colors[level] + l.prefix(level) + colorReset
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	tt := []struct {
+		name string
+		mode ColorMode
+		f    func(l *Logger)
+		want string // pattern
+	}{
+		{"never", ColorNever, func(l *Logger) { l.Warning("Ciao") }, regexp.QuoteMeta(lp[1] + "Ciao")},
+		{"auto buffer", ColorAuto, func(l *Logger) { l.Error("Ciao") }, regexp.QuoteMeta(lp[2] + "Ciao")},
+		{"always warning", ColorAlways, func(l *Logger) { l.Warning("Ciao") }, regexp.QuoteMeta("\x1b[33m" + lp[1] + "\x1b[0mCiao")},
+		{"always error", ColorAlways, func(l *Logger) { l.Error("Ciao") }, regexp.QuoteMeta("\x1b[31m" + lp[2] + "\x1b[0mCiao")},
+		{"always verbose", ColorAlways, func(l *Logger) { l.Verbose(true); l.Info("Ciao") }, "color_test.go:[0-9]+: " + regexp.QuoteMeta("\x1b[32m"+lp[0]+"\x1b[0mCiao")},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetColor(tc.mode)
+			tc.f(l)
+
+			pattern := ts + tc.want + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestColorAutoFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	l := New(LevelInfo)
+	l.SetWriter(f)
+	l.SetColor(ColorAuto)
+	l.Error("Ciao")
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("\x1b[")) {
+		t.Errorf("want no ANSI escapes, got %q", b)
+	}
+}
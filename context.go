@@ -0,0 +1,188 @@
+package log
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetContextExtractor sets the function extracting fields from the context
+// of the standard logger messages, see Logger.SetContextExtractor.
+func SetContextExtractor(fn func(context.Context) []Field) {
+	std.SetContextExtractor(fn)
+}
+
+// SetTraceExtractor sets the function extracting the trace and span IDs from
+// the context of the standard logger messages, see Logger.SetTraceExtractor.
+func SetTraceExtractor(fn func(context.Context) (traceID, spanID string)) {
+	std.SetTraceExtractor(fn)
+}
+
+// Start returns a child of the standard logger carrying the given fields
+// and a copy of ctx holding it, see Logger.Start.
+func Start(ctx context.Context, keys ...interface{}) (context.Context, *Logger) {
+	return std.Start(ctx, keys...)
+}
+
+// loggerKey is the context key of the logger stored by Start.
+type loggerKey struct{}
+
+// Start returns a child logger carrying the given fields (see With) and a
+// copy of ctx holding it, for FromContext to retrieve downstream, e.g. in an
+// HTTP middleware enriching the logger with the request ID.
+func (l *Logger) Start(ctx context.Context, keys ...interface{}) (context.Context, *Logger) {
+	c := l.With(keys...)
+	return context.WithValue(ctx, loggerKey{}, c), c
+}
+
+// FromContext returns the logger stored in ctx by Start or, if there is
+// none, a child of the standard logger (see With), so that its methods
+// report their caller as those of any other logger do.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey{}).(*Logger); ok {
+			return l
+		}
+	}
+	return std.With()
+}
+
+// TraceContext logs a Trace level message on the standard output, with
+// the fields extracted from ctx, see Logger.TraceContext.
+func TraceContext(ctx context.Context, v ...interface{}) {
+	std.TraceContext(ctx, v...)
+}
+
+// DebugContext logs a Debug level message on the standard output, with
+// the fields extracted from ctx, see Logger.DebugContext.
+func DebugContext(ctx context.Context, v ...interface{}) {
+	std.DebugContext(ctx, v...)
+}
+
+// InfoContext logs a Info level message on the standard output, with
+// the fields extracted from ctx, see Logger.InfoContext.
+func InfoContext(ctx context.Context, v ...interface{}) {
+	std.InfoContext(ctx, v...)
+}
+
+// WarningContext logs a Warning level message on the standard output, with
+// the fields extracted from ctx, see Logger.WarningContext.
+func WarningContext(ctx context.Context, v ...interface{}) {
+	std.WarningContext(ctx, v...)
+}
+
+// ErrorContext logs a Error level message on the standard output, with
+// the fields extracted from ctx, see Logger.ErrorContext.
+func ErrorContext(ctx context.Context, v ...interface{}) {
+	std.ErrorContext(ctx, v...)
+}
+
+// SetContextExtractor sets the function extracting fields, such as a trace
+// ID, from the context passed to the Context methods. The fields are
+// appended to the message after the logger fields (see With).
+// Without an extractor, or with a nil context, the Context methods behave
+// like their plain counterparts.
+func (l *Logger) SetContextExtractor(fn func(context.Context) []Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.ctxFields = fn
+}
+
+// Keys of the fields carrying the IDs extracted by SetTraceExtractor.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// SetTraceExtractor sets the function extracting the trace and span IDs from
+// the context passed to the Context methods, whatever the tracing library.
+// They are added as the TraceIDKey and SpanIDKey fields, after those of the
+// context extractor (see SetContextExtractor); an empty ID adds no field.
+func (l *Logger) SetTraceExtractor(fn func(context.Context) (traceID, spanID string)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.traceIDs = fn
+}
+
+// TraceContext logs a Trace level message, with the fields extracted from
+// ctx appended (see SetContextExtractor).
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func (l *Logger) TraceContext(ctx context.Context, v ...interface{}) {
+	if l.Level() > LevelTrace {
+		return
+	}
+	l.outputContext(ctx, LevelTrace, fmt.Sprint(v...))
+}
+
+// DebugContext logs a Debug level message, with the fields extracted from
+// ctx appended (see SetContextExtractor).
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (l *Logger) DebugContext(ctx context.Context, v ...interface{}) {
+	if l.Level() > LevelDebug {
+		return
+	}
+	l.outputContext(ctx, LevelDebug, fmt.Sprint(v...))
+}
+
+// InfoContext logs a Info level message, with the fields extracted from
+// ctx appended (see SetContextExtractor).
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelInfo.
+func (l *Logger) InfoContext(ctx context.Context, v ...interface{}) {
+	if l.Level() > LevelInfo {
+		return
+	}
+	l.outputContext(ctx, LevelInfo, fmt.Sprint(v...))
+}
+
+// WarningContext logs a Warning level message, with the fields extracted from
+// ctx appended (see SetContextExtractor).
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelWarning.
+func (l *Logger) WarningContext(ctx context.Context, v ...interface{}) {
+	if l.Level() > LevelWarning {
+		return
+	}
+	l.outputContext(ctx, LevelWarning, fmt.Sprint(v...))
+}
+
+// ErrorContext logs a Error level message, with the fields extracted from
+// ctx appended (see SetContextExtractor).
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
+func (l *Logger) ErrorContext(ctx context.Context, v ...interface{}) {
+	if l.Level() > LevelError {
+		return
+	}
+	l.outputContext(ctx, LevelError, fmt.Sprint(v...))
+}
+
+// outputContext is like output, adding the fields extracted from ctx.
+// It must be called directly by the logging methods for the call depth to hold.
+func (l *Logger) outputContext(ctx context.Context, level Severity, s string) {
+	l.mu.Lock()
+	fn, ids := l.ctxFields, l.traceIDs
+	l.mu.Unlock()
+
+	var extra []Field
+	if fn != nil && ctx != nil {
+		extra = fn(ctx)
+	}
+	if ids != nil && ctx != nil {
+		traceID, spanID := ids(ctx)
+		if traceID != "" {
+			extra = append(extra[:len(extra):len(extra)], Field{Key: TraceIDKey, Value: traceID})
+		}
+		if spanID != "" {
+			extra = append(extra[:len(extra):len(extra)], Field{Key: SpanIDKey, Value: spanID})
+		}
+	}
+
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(l.calldepth+1, 0, level, "", s, extra)
+}
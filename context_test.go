@@ -0,0 +1,167 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+type traceKey struct{}
+
+func TestContext(t *testing.T) {
+	traced := context.WithValue(context.Background(), traceKey{}, "abc123")
+	extract := func(ctx context.Context) []Field {
+		if id, ok := ctx.Value(traceKey{}).(string); ok {
+			return []Field{{Key: "trace", Value: id}}
+		}
+		return nil
+	}
+
+	tt := []struct {
+		name    string
+		extract func(context.Context) []Field
+		f       func(l *Logger)
+		level   Severity
+		want    string
+	}{
+		{"trace", extract, func(l *Logger) { l.TraceContext(traced, "Ciao") }, LevelTrace, tp + "Ciao trace=abc123"},
+		{"debug", extract, func(l *Logger) { l.DebugContext(traced, "Ciao") }, LevelDebug, dp + "Ciao trace=abc123"},
+		{"info", extract, func(l *Logger) { l.InfoContext(traced, "Ciao", 7) }, LevelInfo, lp[0] + "Ciao7 trace=abc123"},
+		{"warning", extract, func(l *Logger) { l.WarningContext(traced, "Ciao") }, LevelInfo, lp[1] + "Ciao trace=abc123"},
+		{"error", extract, func(l *Logger) { l.ErrorContext(traced, "Ciao") }, LevelInfo, lp[2] + "Ciao trace=abc123"},
+		{"disabled", extract, func(l *Logger) { l.InfoContext(traced, "Ciao") }, LevelWarning, ""},
+		{"no value", extract, func(l *Logger) { l.InfoContext(context.Background(), "Ciao") }, LevelInfo, lp[0] + "Ciao"},
+		{"nil context", extract, func(l *Logger) { l.InfoContext(nil, "Ciao") }, LevelInfo, lp[0] + "Ciao"},
+		{"no extractor", nil, func(l *Logger) { l.InfoContext(traced, "Ciao") }, LevelInfo, lp[0] + "Ciao"},
+		{"after fields", extract, func(l *Logger) { l.With("a", 1).InfoContext(traced, "Ciao") }, LevelInfo, lp[0] + "Ciao a=1 trace=abc123"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.level)
+			l.SetWriter(w)
+			l.SetContextExtractor(tc.extract)
+			tc.f(l)
+
+			var pattern string
+			if tc.want != "" {
+				pattern = ts + regexp.QuoteMeta(tc.want) + "\n$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) || (tc.want == "" && w.Len() > 0) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestContextPackageLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	SetContextExtractor(func(ctx context.Context) []Field {
+		return []Field{{Key: "trace", Value: ctx.Value(traceKey{})}}
+	})
+	defer SetContextExtractor(nil)
+	SetCallerMinLevel(LevelInfo)
+	defer SetCallerMinLevel(LevelOff)
+	InfoContext(context.WithValue(context.Background(), traceKey{}, "abc123"), "Ciao")
+
+	pattern := ts + "context_test.go:[0-9]+: " + regexp.QuoteMeta(lp[0]+"Ciao trace=abc123") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestContextJSON(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetTimestamp(false)
+	l.SetFormatter(JSONFormatter{})
+	l.SetContextExtractor(func(context.Context) []Field { return []Field{{Key: "trace", Value: "abc123"}} })
+	l.InfoContext(context.Background(), "Ciao")
+
+	if want := `{"level":"info","msg":"Ciao","trace":"abc123"}` + "\n"; w.String() != want {
+		t.Errorf("want %q, got %q", want, w.String())
+	}
+}
+
+type spanKey struct{}
+
+func TestTraceExtractor(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc123")
+	extract := func(ctx context.Context) (string, string) {
+		traceID, _ := ctx.Value(traceKey{}).(string)
+		spanID, _ := ctx.Value(spanKey{}).(string)
+		return traceID, spanID
+	}
+
+	tt := []struct {
+		name      string
+		ctx       context.Context
+		formatter Formatter
+		want      string
+	}{
+		{"trace and span", context.WithValue(ctx, spanKey{}, "def"), nil, lp[0] + "Ciao a=1 trace=x trace_id=abc123 span_id=def\n"},
+		{"no span", ctx, nil, lp[0] + "Ciao a=1 trace=x trace_id=abc123\n"},
+		{"no IDs", context.Background(), nil, lp[0] + "Ciao a=1 trace=x\n"},
+		{"JSON", context.WithValue(ctx, spanKey{}, "def"), JSONFormatter{}, `{"level":"info","msg":"Ciao","a":1,"trace":"x","trace_id":"abc123","span_id":"def"}` + "\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetTimestamp(false)
+			l.SetFormatter(tc.formatter)
+			l.SetContextExtractor(func(context.Context) []Field { return []Field{{Key: "trace", Value: "x"}} })
+			l.SetTraceExtractor(extract)
+			l.With("a", 1).InfoContext(tc.ctx, "Ciao")
+
+			if w.String() != tc.want {
+				t.Errorf("mismatch! Want %q, got %q", tc.want, w.String())
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetTimestamp(false)
+
+	ctx, c := l.Start(context.Background(), "req", 7)
+	if got := FromContext(ctx); got != c {
+		t.Fatalf("FromContext: want the started logger, got %p", got)
+	}
+	FromContext(ctx).Info("Ciao")
+	_, cc := c.Start(ctx, "user", "bob")
+	cc.Info("Ciao")
+
+	if want := lp[0] + "Ciao req=7\n" + lp[0] + "Ciao req=7 user=bob\n"; w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+}
+
+func TestFromContextStd(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	defer SetWriter(os.Stdout)
+	Verbose(true)
+	defer Verbose(false)
+
+	_, _, line, _ := runtime.Caller(0)
+	FromContext(context.Background()).Info("Ciao")
+	FromContext(nil).Warning("Ciao")
+
+	loc := func(n int) string { return regexp.QuoteMeta("context_test.go:" + strconv.Itoa(n) + ": ") }
+	pattern := ts + loc(line+1) + regexp.QuoteMeta(lp[0]+"Ciao") + "\n" + ts[1:] + loc(line+2) + regexp.QuoteMeta(lp[1]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
@@ -0,0 +1,18 @@
+// Package debuglog provides Debug and Trace functions logging through the
+// standard logger of package log only in binaries built with the debuglog
+// build tag:
+//
+//	go build -tags debuglog
+//
+// Without the tag, they are empty functions the compiler inlines away, along
+// with the evaluation of arguments free of side effects, so that debug
+// logging costs nothing in production binaries, which level checks alone
+// cannot achieve. Arguments calling functions are still evaluated; guard
+// them with the Enabled constant, the branch being then compiled out:
+//
+//	if debuglog.Enabled {
+//		debuglog.Debugf("state: %v", expensiveDump())
+//	}
+//
+// With the tag, the functions behave as their counterparts in package log.
+package debuglog
@@ -0,0 +1,49 @@
+package debuglog
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/dpmik/log"
+)
+
+func TestDebuglog(t *testing.T) {
+	w := new(bytes.Buffer)
+	log.SetWriter(w)
+	defer log.SetWriter(os.Stdout)
+	log.SetLevel(log.LevelTrace)
+	defer log.SetLevel(log.LevelInfo)
+	log.Verbose(true)
+	defer log.Verbose(false)
+
+	Debug("Ciao", 7)
+	Debugf("Ciao %d", 7)
+	Trace("Ciao")
+	Tracef("Ciao %s", "you")
+
+	pattern := "^$"
+	if Enabled {
+		line := `[0-9/]{10} [0-9:.]{15} debuglog_test.go:[0-9]+: `
+		pattern = "^" + line + regexp.QuoteMeta(log.Prefix(log.LevelDebug)+"Ciao7") + "\n" +
+			line + regexp.QuoteMeta(log.Prefix(log.LevelDebug)+"Ciao 7") + "\n" +
+			line + regexp.QuoteMeta(log.Prefix(log.LevelTrace)+"Ciao") + "\n" +
+			line + regexp.QuoteMeta(log.Prefix(log.LevelTrace)+"Ciao you") + "\n$"
+	}
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestDebuglogLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	log.SetWriter(w)
+	defer log.SetWriter(os.Stdout)
+	Debug("Ciao")
+	Trace("Ciao")
+
+	if w.Len() > 0 {
+		t.Errorf("want nothing below LevelInfo, got %q", w.String())
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !debuglog
+
+package debuglog
+
+// Enabled reports whether the binary is built with the debuglog tag.
+const Enabled = false
+
+// Debug does nothing without the debuglog tag.
+func Debug(v ...interface{}) {}
+
+// Debugf does nothing without the debuglog tag.
+func Debugf(format string, v ...interface{}) {}
+
+// Trace does nothing without the debuglog tag.
+func Trace(v ...interface{}) {}
+
+// Tracef does nothing without the debuglog tag.
+func Tracef(format string, v ...interface{}) {}
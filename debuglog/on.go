@@ -0,0 +1,40 @@
+//go:build debuglog
+
+package debuglog
+
+import (
+	"fmt"
+
+	"github.com/dpmik/log"
+)
+
+// Enabled reports whether the binary is built with the debuglog tag.
+const Enabled = true
+
+// Debug logs a Debug level message, see log.Debug.
+func Debug(v ...interface{}) {
+	if log.Enabled(log.LevelDebug) {
+		log.Output(2, log.LevelDebug, fmt.Sprint(v...))
+	}
+}
+
+// Debugf logs a Debug level message, see log.Debugf.
+func Debugf(format string, v ...interface{}) {
+	if log.Enabled(log.LevelDebug) {
+		log.Output(2, log.LevelDebug, fmt.Sprintf(format, v...))
+	}
+}
+
+// Trace logs a Trace level message, see log.Trace.
+func Trace(v ...interface{}) {
+	if log.Enabled(log.LevelTrace) {
+		log.Output(2, log.LevelTrace, fmt.Sprint(v...))
+	}
+}
+
+// Tracef logs a Trace level message, see log.Tracef.
+func Tracef(format string, v ...interface{}) {
+	if log.Enabled(log.LevelTrace) {
+		log.Output(2, log.LevelTrace, fmt.Sprintf(format, v...))
+	}
+}
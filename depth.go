@@ -0,0 +1,141 @@
+package log
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SetMaxStructDepth sets the maximum depth of the values rendered by the
+// standard logger, see Logger.SetMaxStructDepth.
+func SetMaxStructDepth(n int) {
+	std.SetMaxStructDepth(n)
+}
+
+// SetMaxStructDepth caps at n the nesting of the structs, maps, slices and
+// arrays rendered for the field values, chiefly by the structured formats
+// (see SetFormatter), by JSON and by Diff, deeper values being replaced by
+// "...", e.g. {"a":1,"b":"..."} for n equal to 1. A value referring back to one
+// enclosing it is replaced by "..." as well, so that cyclic values can be
+// logged. The values are then rendered from their exported fields, or their
+// own encoding if they implement json.Marshaler or encoding.TextMarshaler,
+// errors by their message.
+// A value of n less than or equal to zero removes the cap (default).
+func (l *Logger) SetMaxStructDepth(n int) {
+	l.maxDepth.Store(int64(n))
+}
+
+// limit returns v capped to the depth set by SetMaxStructDepth.
+func (l *Logger) limit(v interface{}) interface{} {
+	n := int(l.maxDepth.Load())
+	if n <= 0 {
+		return v
+	}
+	return truncate(reflect.ValueOf(v), n, map[uintptr]bool{})
+}
+
+// limitFields returns fields with their values capped by limit, fields
+// itself if there is no cap.
+func (l *Logger) limitFields(fields []Field) []Field {
+	if l.maxDepth.Load() <= 0 || len(fields) == 0 {
+		return fields
+	}
+	limited := make([]Field, len(fields))
+	for i, f := range fields {
+		limited[i] = Field{Key: f.Key, Value: l.limit(f.Value)}
+	}
+	return limited
+}
+
+// tooDeep replaces the values beyond the maximum depth.
+const tooDeep = "..."
+
+// truncate returns v with the structs, maps, slices and arrays nested deeper
+// than depth replaced by tooDeep, as are the values already in seen, the
+// addresses of the values enclosing v.
+func truncate(v reflect.Value, depth int, seen map[uintptr]bool) interface{} {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case error:
+			return x.Error()
+		case json.Marshaler, encoding.TextMarshaler:
+			return x
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.Kind() == reflect.Ptr {
+			if seen[v.Pointer()] {
+				return tooDeep
+			}
+			seen[v.Pointer()] = true
+			defer delete(seen, v.Pointer())
+		}
+		return truncate(v.Elem(), depth, seen)
+	case reflect.Struct:
+		if depth == 0 {
+			return tooDeep
+		}
+		m := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			m[name] = truncate(v.Field(i), depth-1, seen)
+		}
+		return m
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if depth == 0 || seen[v.Pointer()] {
+			return tooDeep
+		}
+		seen[v.Pointer()] = true
+		defer delete(seen, v.Pointer())
+
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = truncate(iter.Value(), depth-1, seen)
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface() // bytes
+		}
+		if depth == 0 {
+			return tooDeep
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			if seen[v.Pointer()] {
+				return tooDeep
+			}
+			seen[v.Pointer()] = true
+			defer delete(seen, v.Pointer())
+		}
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = truncate(v.Index(i), depth-1, seen)
+		}
+		return s
+	}
+	if !v.CanInterface() {
+		return fmt.Sprint(v)
+	}
+	return v.Interface()
+}
@@ -0,0 +1,89 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// node is a value possibly nested or cyclic.
+type node struct {
+	Name   string
+	Next   *node `json:"next,omitempty"`
+	hidden int
+}
+
+func TestMaxStructDepthJSONFields(t *testing.T) {
+	cycle := &node{Name: "a"}
+	cycle.Next = &node{Name: "b", Next: cycle}
+	self := map[string]interface{}{"k": 1}
+	self["self"] = self
+
+	tt := []struct {
+		name  string
+		depth int
+		v     interface{}
+		want  string
+	}{
+		{"no cap", 0, &node{Name: "a", Next: &node{Name: "b"}}, `{"Name":"a","next":{"Name":"b"}}`},
+		{"depth 1", 1, &node{Name: "a", Next: &node{Name: "b"}}, `{"Name":"a","next":"..."}`},
+		{"depth 2", 2, &node{Name: "a", Next: &node{Name: "b"}}, `{"Name":"a","next":{"Name":"b","next":null}}`},
+		{"cycle", 10, cycle, `{"Name":"a","next":{"Name":"b","next":"..."}}`},
+		{"map cycle", 10, self, `{"k":1,"self":"..."}`},
+		{"slice", 1, []interface{}{1, []int{2}}, `[1,"..."]`},
+		{"bytes", 1, []byte("hi"), `"aGk="`},
+		{"scalar", 1, 7, `7`},
+		{"marshaler", 1, map[string]interface{}{"t": time.Unix(0, 0).UTC()}, `{"t":"1970-01-01T00:00:00Z"}`},
+		{"error", 2, map[string]interface{}{"err": errors.New("boom")}, `{"err":"boom"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetTimestamp(false)
+			l.SetFormatter(JSONFormatter{})
+			l.SetMaxStructDepth(tc.depth)
+			l.Infow("Ciao", "v", tc.v)
+
+			want := `{"level":"info","msg":"Ciao","v":` + tc.want + "}\n"
+			if w.String() != want {
+				t.Errorf("mismatch! Want %q, got %q", want, w.String())
+			}
+		})
+	}
+}
+
+func TestMaxStructDepthDumpDiff(t *testing.T) {
+	cycle := &node{Name: "a"}
+	cycle.Next = cycle
+
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetTimestamp(false)
+	l.SetMaxStructDepth(1)
+	l.With().JSON(LevelInfo, "node", cycle)
+	l.Diff(LevelInfo, "cfg", map[string]interface{}{"n": 1}, map[string]interface{}{"n": cycle})
+
+	want := lp[0] + "node: {\n  \"Name\": \"a\",\n  \"next\": \"...\"\n}\n" +
+		lp[0] + "cfg: n: 1 -> map[Name:a next:...]\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+}
+
+func TestMaxStructDepthText(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetMaxStructDepth(1)
+	self := map[string]interface{}{"k": 1}
+	self["self"] = self
+	l.With("u", self).Infow("Ciao", "v", node{Name: "a"}, "w", self)
+
+	pattern := ts + regexp.QuoteMeta(lp[0]+"Ciao u=map[k:1 self:...] v=map[Name:a next:<nil>] w=map[k:1 self:...]") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
@@ -0,0 +1,109 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Diff logs the differences between before and after, one line per change.
+func Diff(level Severity, name string, before, after interface{}) {
+	std.Diff(level, name, before, after)
+}
+
+// Diff logs the differences between before and after, one line per change,
+// as a contiguous block. For structs of the same type, changed exported fields
+// are logged as "name: field: old -> new"; for maps, keys are reported as
+// added, removed or changed. Other values are compared as a whole.
+// Nothing is logged when the values are equal. The depth of the logged
+// values is capped by SetMaxStructDepth.
+func (l *Logger) Diff(level Severity, name string, before, after interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	changes := diff(reflect.ValueOf(before), reflect.ValueOf(after), l.limit)
+	if len(changes) == 0 {
+		return
+	}
+
+	l.omu.Lock()
+	defer l.unlock()
+
+	for _, c := range changes {
+		l.emit(l.calldepth, 0, level, "", name+": "+c, nil)
+	}
+}
+
+// diff returns the description of the changes between a and b, the values
+// being rendered through show.
+func diff(a, b reflect.Value, show func(interface{}) interface{}) []string {
+	for a.Kind() == reflect.Ptr && b.Kind() == reflect.Ptr && !a.IsNil() && !b.IsNil() {
+		a, b = a.Elem(), b.Elem()
+	}
+
+	if a.IsValid() && b.IsValid() && a.Type() == b.Type() {
+		switch a.Kind() {
+		case reflect.Struct:
+			return diffStruct(a, b, show)
+		case reflect.Map:
+			return diffMap(a, b, show)
+		}
+	}
+
+	if reflect.DeepEqual(valueOf(a), valueOf(b)) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%v -> %v", show(valueOf(a)), show(valueOf(b)))}
+}
+
+// diffStruct compares the exported fields of the structs a and b.
+func diffStruct(a, b reflect.Value, show func(interface{}) interface{}) []string {
+	var changes []string
+	for i := 0; i < a.NumField(); i++ {
+		f := a.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		x, y := a.Field(i).Interface(), b.Field(i).Interface()
+		if !reflect.DeepEqual(x, y) {
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", f.Name, show(x), show(y)))
+		}
+	}
+	return changes
+}
+
+// diffMap compares the entries of the maps a and b, sorted by key.
+func diffMap(a, b reflect.Value, show func(interface{}) interface{}) []string {
+	keys := map[string]reflect.Value{}
+	for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+		keys[fmt.Sprint(k.Interface())] = k
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []string
+	for _, name := range names {
+		x, y := a.MapIndex(keys[name]), b.MapIndex(keys[name])
+		switch {
+		case !x.IsValid():
+			changes = append(changes, fmt.Sprintf("%s: added %v", name, show(y.Interface())))
+		case !y.IsValid():
+			changes = append(changes, fmt.Sprintf("%s: removed %v", name, show(x.Interface())))
+		case !reflect.DeepEqual(x.Interface(), y.Interface()):
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", name, show(x.Interface()), show(y.Interface())))
+		}
+	}
+	return changes
+}
+
+// valueOf returns the value held by v, or nil for the zero Value.
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,71 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type config struct {
+		Host   string
+		Port   int
+		Tags   []string
+		secret string
+	}
+
+	tt := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   []string
+	}{
+		{"equal structs", config{Host: "a"}, config{Host: "a"}, nil},
+		{"struct fields", config{Host: "a", Port: 1, Tags: []string{"x"}}, config{Host: "b", Port: 1, Tags: []string{"y"}}, []string{"Host: a -> b", "Tags: [x] -> [y]"}},
+		{"unexported ignored", config{secret: "a"}, config{secret: "b"}, nil},
+		{"struct pointers", &config{Port: 1}, &config{Port: 2}, []string{"Port: 1 -> 2"}},
+		{"maps", map[string]int{"a": 1, "b": 2, "c": 3}, map[string]int{"b": 2, "c": 4, "d": 5}, []string{"a: removed 1", "c: 3 -> 4", "d: added 5"}},
+		{"scalars", 1, 2, []string{"1 -> 2"}},
+		{"slices", []int{1}, []int{1, 2}, []string{"[1] -> [1 2]"}},
+		{"different types", 1, "1", []string{"1 -> 1"}},
+		{"nil to value", nil, 1, []string{"<nil> -> 1"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.Diff(LevelInfo, "cfg", tc.before, tc.after)
+
+			if tc.want == nil {
+				if w.Len() != 0 {
+					t.Fatalf("want no output, got %q", w.String())
+				}
+				return
+			}
+			lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+			if len(lines) != len(tc.want) {
+				t.Fatalf("want %d lines, got %q", len(tc.want), lines)
+			}
+			for i, line := range lines {
+				pattern := ts + regexp.QuoteMeta(lp[0]+"cfg: "+tc.want[i]) + "$"
+				if !regexp.MustCompile(pattern).MatchString(line) {
+					t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelError)
+	l.SetWriter(w)
+	l.Diff(LevelWarning, "cfg", 1, 2)
+
+	if w.Len() != 0 {
+		t.Errorf("want no output below level, got %q", w.String())
+	}
+}
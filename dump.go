@@ -0,0 +1,26 @@
+package log
+
+import "encoding/json"
+
+// JSON logs label followed by the indented JSON encoding of v.
+func JSON(level Severity, label string, v interface{}) {
+	std.JSON(level, label, v)
+}
+
+// JSON logs label followed by the indented JSON encoding of v, as a single
+// message spanning multiple lines. If v cannot be encoded, the encoding error
+// is logged in place of the value. Its depth is capped by SetMaxStructDepth.
+func (l *Logger) JSON(level Severity, label string, v interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	s := label + ": "
+	b, err := json.MarshalIndent(l.limit(v), "", "  ")
+	if err != nil {
+		s += err.Error()
+	} else {
+		s += string(b)
+	}
+	l.output(level, s)
+}
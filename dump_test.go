@@ -0,0 +1,46 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	tt := []struct {
+		name  string
+		level Severity
+		min   Severity
+		v     interface{}
+		want  string
+	}{
+		{"struct", LevelInfo, LevelInfo, point{1, 2}, lp[0] + "p: {\n  \"X\": 1,\n  \"Y\": 2\n}\n"},
+		{"scalar", LevelWarning, LevelInfo, 7, lp[1] + "p: 7\n"},
+		{"marshal error", LevelError, LevelInfo, func() {}, lp[2] + "p: json: unsupported type: func()\n"},
+		{"below level", LevelInfo, LevelWarning, point{1, 2}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.min)
+			l.SetWriter(w)
+			l.JSON(tc.level, "p", tc.v)
+
+			if tc.want == "" {
+				if w.Len() != 0 {
+					t.Fatalf("want no output, got %q", w.String())
+				}
+				return
+			}
+			pattern := ts + regexp.QuoteMeta(tc.want) + "$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
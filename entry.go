@@ -0,0 +1,189 @@
+package log
+
+import "fmt"
+
+// ErrorKey is the key of the field added by WithError.
+const ErrorKey = "error"
+
+// Fields is a set of fields, see WithFields.
+type Fields map[string]interface{}
+
+// Entry is a logger carrying fields, with the fluent API of logrus to ease
+// the migration from it. It maps one to one onto this package:
+//
+//	logrus                        log
+//	logrus.WithField(k, v)        log.WithField(k, v)
+//	logrus.WithFields(f)          log.WithFields(log.Fields(f))
+//	logrus.WithError(err)         log.WithError(err)
+//	entry.WithField(k, v)         entry.WithField(k, v)
+//	entry.Debug, Debugf           entry.Debug, Debugf
+//	entry.Info, Infof             entry.Info, Infof
+//	entry.Warn, Warnf             entry.Warn, Warnf
+//	entry.Error, Errorf           entry.Error, Errorf
+//	entry.Fatal, Fatalf           entry.Fatal, Fatalf
+//
+// Every field is added by Logger.With, so the fields render as " key=value"
+// sorted by key, and a key given again replaces the previous value.
+type Entry struct {
+	l *Logger
+}
+
+// WithField returns an entry of the standard logger carrying the field k,
+// see Logger.WithField.
+func WithField(k string, v interface{}) *Entry {
+	return std.WithField(k, v)
+}
+
+// WithFields returns an entry of the standard logger carrying fields,
+// see Logger.WithFields.
+func WithFields(fields Fields) *Entry {
+	return std.WithFields(fields)
+}
+
+// WithError returns an entry of the standard logger carrying err,
+// see Logger.WithError.
+func WithError(err error) *Entry {
+	return std.WithError(err)
+}
+
+// WithField returns an entry carrying the field k, starting a logrus style
+// chain, see Entry.
+func (l *Logger) WithField(k string, v interface{}) *Entry {
+	return &Entry{l.With(k, v)}
+}
+
+// WithFields returns an entry carrying fields, see Entry.
+func (l *Logger) WithFields(fields Fields) *Entry {
+	kv := make([]interface{}, 0, 2*len(fields))
+	for k, v := range fields {
+		kv = append(kv, k, v)
+	}
+	return &Entry{l.With(kv...)}
+}
+
+// WithError returns an entry carrying err under ErrorKey, see Entry.
+func (l *Logger) WithError(err error) *Entry {
+	return l.WithField(ErrorKey, err)
+}
+
+// WithField returns an entry carrying the fields of e and the field k.
+func (e *Entry) WithField(k string, v interface{}) *Entry {
+	return e.l.WithField(k, v)
+}
+
+// WithFields returns an entry carrying the fields of e and fields.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	return e.l.WithFields(fields)
+}
+
+// WithError returns an entry carrying the fields of e and err under ErrorKey.
+func (e *Entry) WithError(err error) *Entry {
+	return e.l.WithError(err)
+}
+
+// Logger returns the logger carrying the fields of e.
+func (e *Entry) Logger() *Logger {
+	return e.l
+}
+
+// Debug logs a Debug level message with the fields of e.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (e *Entry) Debug(v ...interface{}) {
+	if e.l.Level() > LevelDebug {
+		return
+	}
+	e.l.output(LevelDebug, fmt.Sprint(v...))
+}
+
+// Debugf logs a Debug level message with the fields of e.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (e *Entry) Debugf(format string, v ...interface{}) {
+	if e.l.Level() > LevelDebug {
+		return
+	}
+	e.l.output(LevelDebug, fmt.Sprintf(format, v...))
+}
+
+// Info logs an Info level message with the fields of e.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelInfo.
+func (e *Entry) Info(v ...interface{}) {
+	if e.l.Level() > LevelInfo {
+		return
+	}
+	e.l.output(LevelInfo, fmt.Sprint(v...))
+}
+
+// Infof logs an Info level message with the fields of e.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelInfo.
+func (e *Entry) Infof(format string, v ...interface{}) {
+	if e.l.Level() > LevelInfo {
+		return
+	}
+	e.l.output(LevelInfo, fmt.Sprintf(format, v...))
+}
+
+// Warn logs a Warning level message with the fields of e.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelWarning.
+func (e *Entry) Warn(v ...interface{}) {
+	if e.l.Level() > LevelWarning {
+		return
+	}
+	e.l.output(LevelWarning, fmt.Sprint(v...))
+}
+
+// Warnf logs a Warning level message with the fields of e.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelWarning.
+func (e *Entry) Warnf(format string, v ...interface{}) {
+	if e.l.Level() > LevelWarning {
+		return
+	}
+	e.l.output(LevelWarning, fmt.Sprintf(format, v...))
+}
+
+// Error logs an Error level message with the fields of e.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
+func (e *Entry) Error(v ...interface{}) {
+	if e.l.Level() > LevelError {
+		return
+	}
+	e.l.output(LevelError, fmt.Sprint(e.l.formatErrors(v)...))
+}
+
+// Errorf logs an Error level message with the fields of e.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
+func (e *Entry) Errorf(format string, v ...interface{}) {
+	if e.l.Level() > LevelError {
+		return
+	}
+	e.l.output(LevelError, fmt.Sprintf(format, e.l.formatErrors(v)...))
+}
+
+// Fatal logs an Error level message with the fields of e and calls
+// os.Exit(1), see Logger.Fatal.
+// Arguments are handled in the manner of fmt.Print.
+func (e *Entry) Fatal(v ...interface{}) {
+	msg := fmt.Sprint(v...)
+	if e.l.Level() <= LevelError {
+		e.l.output(LevelError, msg)
+	}
+	e.l.exit(msg)
+}
+
+// Fatalf logs an Error level message with the fields of e and calls
+// os.Exit(1), see Logger.Fatal.
+// Arguments are handled in the manner of fmt.Printf.
+func (e *Entry) Fatalf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	if e.l.Level() <= LevelError {
+		e.l.output(LevelError, msg)
+	}
+	e.l.exit(msg)
+}
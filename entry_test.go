@@ -0,0 +1,58 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestEntry(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		f     func(l *Logger)
+		want  string
+	}{
+		{"field", LevelInfo, func(l *Logger) { l.WithField("b", 2).WithField("a", 1).Info("Ciao", 7) }, lp[0] + "Ciao7 a=1 b=2"},
+		{"fields", LevelInfo, func(l *Logger) { l.WithFields(Fields{"b": 2, "a": 1}).Warnf("Ciao %d", 7) }, lp[1] + "Ciao 7 a=1 b=2"},
+		{"error", LevelInfo, func(l *Logger) { l.WithError(errors.New("boom")).WithField("a", 1).Error("Ciao") }, lp[2] + "Ciao a=1 error=boom"},
+		{"replaced", LevelInfo, func(l *Logger) { l.WithField("a", 1).WithFields(Fields{"a": 2}).Errorf("Ciao") }, lp[2] + "Ciao a=2"},
+		{"debug", LevelDebug, func(l *Logger) { l.WithField("a", 1).Debugf("Ciao") }, dp + "Ciao a=1"},
+		{"disabled", LevelWarning, func(l *Logger) { l.WithField("a", 1).Info("Ciao") }, ""},
+		{"fatal", LevelInfo, func(l *Logger) { l.WithField("a", 1).Fatal("Ciao") }, lp[2] + "Ciao a=1"},
+		{"parent unchanged", LevelInfo, func(l *Logger) { l.WithField("a", 1); l.Info("Ciao") }, lp[0] + "Ciao"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.level, WithWriter(w), WithVerbose(true))
+			l.SetFatalPolicy(func(string) bool { return false })
+			tc.f(l)
+
+			pattern := "^$"
+			if tc.want != "" {
+				pattern = ts + "entry_test.go:[0-9]+: " + regexp.QuoteMeta(tc.want) + "\n$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestEntryPackageLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	defer SetWriter(os.Stdout)
+	Verbose(true)
+	defer Verbose(false)
+	WithField("a", 1).WithFields(Fields{"b": 2}).WithError(errors.New("boom")).Info("Ciao")
+
+	pattern := ts + "entry_test.go:[0-9]+: " + regexp.QuoteMeta(lp[0]+"Ciao a=1 b=2 error=boom") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
@@ -0,0 +1,18 @@
+package log
+
+import "os"
+
+// EnvKey is the environment variable read by NewFromEnv.
+var EnvKey = "LOG_LEVEL"
+
+// NewFromEnv instantiates a new Logger with the level named by the EnvKey
+// environment variable (see ParseLevel), or LevelInfo if the variable is
+// unset or invalid.
+// The variable is only read here: a later SetLevel takes precedence.
+func NewFromEnv() *Logger {
+	level, err := ParseLevel(os.Getenv(EnvKey))
+	if err != nil {
+		level = LevelInfo
+	}
+	return New(level)
+}
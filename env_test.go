@@ -0,0 +1,44 @@
+package log
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	tt := []struct {
+		name  string
+		key   string
+		value string // unset if empty
+		want  Severity
+	}{
+		{"unset", "LOG_LEVEL", "", LevelInfo},
+		{"valid", "LOG_LEVEL", "warn", LevelWarning},
+		{"valid spaces", "LOG_LEVEL", " Debug ", LevelDebug},
+		{"invalid", "LOG_LEVEL", "loud", LevelInfo},
+		{"custom key", "MY_LEVEL", "error", LevelError},
+	}
+
+	defer func(key string) { EnvKey = key }(EnvKey)
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			EnvKey = tc.key
+			t.Setenv(tc.key, tc.value)
+			if tc.value == "" {
+				os.Unsetenv(tc.key) // #nosec, restored by Setenv
+			}
+			if got := NewFromEnv().Level(); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewFromEnvSetLevel(t *testing.T) {
+	t.Setenv(EnvKey, "error")
+	l := NewFromEnv()
+	l.SetLevel(LevelDebug)
+	if got := l.Level(); got != LevelDebug {
+		t.Errorf("want SetLevel to take precedence, got %v", got)
+	}
+}
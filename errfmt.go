@@ -0,0 +1,94 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SetErrorFormatter sets the function rendering the errors logged by the
+// standard logger, see Logger.SetErrorFormatter.
+func SetErrorFormatter(fn func(error) string) {
+	std.SetErrorFormatter(fn)
+}
+
+// SetErrorFormatter sets the function rendering the error arguments of
+// Error, Errorf and Errorln, e.g. to print the whole chain of a wrapped error
+// or its stack trace; with Errorf, it applies to the errors printed by the
+// %v and %s verbs. A nil fn restores the default of printing err.Error().
+func (l *Logger) SetErrorFormatter(fn func(error) string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.errFormat = fn
+}
+
+// ErrorChain is an error formatter (see SetErrorFormatter) rendering err
+// followed by the errors it wraps, one per line, and by the stack trace of
+// the first error of the chain having a StackTrace method, as the errors of
+// github.com/pkg/errors do.
+func ErrorChain(err error) string {
+	var b strings.Builder
+	b.WriteString(err.Error())
+	var stack interface{}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if e != err {
+			b.WriteString("\n\tcaused by: ")
+			b.WriteString(e.Error())
+		}
+		if stack == nil {
+			stack = stackTrace(e)
+		}
+	}
+	if stack != nil {
+		fmt.Fprintf(&b, "\n%+v", stack)
+	}
+	return b.String()
+}
+
+// stackTrace returns the result of the StackTrace method of err, or nil.
+func stackTrace(err error) interface{} {
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+	return m.Call(nil)[0].Interface()
+}
+
+// formatErrors returns v with its errors rendered by the error formatter,
+// or v itself if there is none.
+func (l *Logger) formatErrors(v []interface{}) []interface{} {
+	l.mu.Lock()
+	fn := l.errFormat
+	l.mu.Unlock()
+
+	if fn == nil {
+		return v
+	}
+	out := make([]interface{}, len(v))
+	for i, a := range v {
+		if err, ok := a.(error); ok {
+			a = formattedError{err: err, text: fn(err)}
+		}
+		out[i] = a
+	}
+	return out
+}
+
+// formattedError is an error with the text given by the error formatter.
+// Not being a string, it keeps the spacing of fmt.Sprint.
+type formattedError struct {
+	err  error
+	text string
+}
+
+// Error returns the formatted text.
+func (e formattedError) Error() string {
+	return e.text
+}
+
+// Unwrap returns the original error.
+func (e formattedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,87 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// chain renders err and the errors it wraps, one per line.
+func chain(err error) string {
+	var b strings.Builder
+	for ; err != nil; err = errors.Unwrap(err) {
+		fmt.Fprintf(&b, "\n\t%T: %v", err, err)
+	}
+	return "error chain:" + b.String()
+}
+
+func TestSetErrorFormatter(t *testing.T) {
+	base := errors.New("disk full")
+	err := fmt.Errorf("saving: %w", base)
+
+	tt := []struct {
+		name string
+		fn   func(error) string
+		f    func(l *Logger)
+		want string
+	}{
+		{"default", nil, func(l *Logger) { l.Error(err) }, lp[2] + "saving: disk full"},
+		{"default Errorf", nil, func(l *Logger) { l.Errorf("failed: %v", err) }, lp[2] + "failed: saving: disk full"},
+		{"sole argument", chain, func(l *Logger) { l.Error(err) }, lp[2] + "error chain:\n\t*fmt.wrapError: saving: disk full\n\t*errors.errorString: disk full"},
+		{"Errorf", chain, func(l *Logger) { l.Errorf("failed: %v (%s)", base, base) }, lp[2] + "failed: error chain:\n\t*errors.errorString: disk full (error chain:\n\t*errors.errorString: disk full)"},
+		{"Errorln", func(err error) string { return "<" + err.Error() + ">" }, func(l *Logger) { l.Errorln("failed:", err) }, lp[2] + "failed: <saving: disk full>"},
+		{"spacing", func(err error) string { return "<" + err.Error() + ">" }, func(l *Logger) { l.Error(7, base) }, lp[2] + "7 <disk full>"},
+		{"other arguments", chain, func(l *Logger) { l.Errorf("%d %s", 7, "ciao") }, lp[2] + "7 ciao"},
+		{"other levels", chain, func(l *Logger) { l.Warning(err) }, lp[1] + "saving: disk full"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetErrorFormatter(tc.fn)
+			tc.f(l)
+
+			pattern := ts + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+// stackError is an error with a stack trace, in the manner of github.com/pkg/errors.
+type stackError struct {
+	error
+}
+
+func (stackError) StackTrace() []string {
+	return []string{"main.main", "runtime.main"}
+}
+
+func TestErrorChain(t *testing.T) {
+	base := errors.New("disk full")
+
+	tt := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"plain", base, "disk full"},
+		{"wrapped", fmt.Errorf("saving: %w", base), "saving: disk full\n\tcaused by: disk full"},
+		{"twice", fmt.Errorf("request: %w", fmt.Errorf("saving: %w", base)), "request: saving: disk full\n\tcaused by: saving: disk full\n\tcaused by: disk full"},
+		{"stack", stackError{base}, "disk full\n[main.main runtime.main]"},
+		{"wrapped stack", fmt.Errorf("saving: %w", stackError{base}), "saving: disk full\n\tcaused by: disk full\n[main.main runtime.main]"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ErrorChain(tc.err); got != tc.want {
+				t.Errorf("mismatch! Want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package log
+
+// Event is a predeclared structured message: its text, level and field keys
+// are set once, the field values given on every log, keeping the schema of
+// the message the same across calls:
+//
+//	slow := l.Event(LevelInfo, "request served", "path", "latency")
+//	slow.Escalate(d > time.Second, LevelWarning).Emit(r.URL.Path, d)
+type Event struct {
+	l     *Logger
+	depth int // call depth of the methods, see Logger.SetCallDepth
+	level Severity
+	msg   string
+	keys  []string
+}
+
+// ExtraKey is the key of the field holding the values given to an Event
+// beyond its keys.
+const ExtraKey = "extra"
+
+// NewEvent returns an event of the standard logger, see Logger.Event.
+func NewEvent(level Severity, msg string, keys ...string) *Event {
+	return &Event{l: std, depth: 2, level: level, msg: msg, keys: keys}
+}
+
+// Event returns an event logging msg at the given level with the fields
+// named by keys, see Event.
+func (l *Logger) Event(level Severity, msg string, keys ...string) *Event {
+	return &Event{l: l, depth: l.CallDepth(), level: level, msg: msg, keys: keys}
+}
+
+// Level returns the level of e.
+func (e *Event) Level() Severity {
+	return e.level
+}
+
+// Escalate returns e with its level raised to level if cond is true, e.g.
+// to log a slow request as a warning, or e itself otherwise. A level lower
+// than the one of e leaves it unchanged.
+func (e *Event) Escalate(cond bool, level Severity) *Event {
+	if !cond || level <= e.level {
+		return e
+	}
+	c := *e
+	c.level = level
+	return &c
+}
+
+// Emit logs the event at its level with the given field values, see Log.
+// Log message is emitted only if the current logging level is equal or less than the event level.
+func (e *Event) Emit(values ...interface{}) {
+	if !e.l.Enabled(e.level) {
+		return
+	}
+	e.l.outputFieldsDepth(e.depth, e.level, e.msg, e.fields(values))
+}
+
+// Log logs the event at the given level, overriding the event one, with
+// values as the values of the event keys, in order. A key without a value
+// gets "<missing>" and the values beyond the keys are logged under ExtraKey.
+// Log message is emitted only if the current logging level is equal or less than level.
+func (e *Event) Log(level Severity, values ...interface{}) {
+	if !e.l.Enabled(level) {
+		return
+	}
+	e.l.outputFieldsDepth(e.depth, level, e.msg, e.fields(values))
+}
+
+// fields returns the key/value pairs of the event keys and values.
+func (e *Event) fields(values []interface{}) []interface{} {
+	kv := make([]interface{}, 0, 2*len(e.keys)+2)
+	for i, k := range e.keys {
+		var v interface{} = "<missing>"
+		if i < len(values) {
+			v = values[i]
+		}
+		kv = append(kv, k, v)
+	}
+	if len(values) > len(e.keys) {
+		kv = append(kv, ExtraKey, values[len(e.keys):])
+	}
+	return kv
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestEvent(t *testing.T) {
+	tt := []struct {
+		name string
+		log  func(e *Event)
+		want string
+	}{
+		{"emit", func(e *Event) { e.Emit("/users", 7) }, lp[0] + "served path=/users n=7"},
+		{"log", func(e *Event) { e.Log(LevelError, "/users", 7) }, lp[2] + "served path=/users n=7"},
+		{"escalate", func(e *Event) { e.Escalate(true, LevelWarning).Emit("/users", 7) }, lp[1] + "served path=/users n=7"},
+		{"no escalate", func(e *Event) { e.Escalate(false, LevelWarning).Emit("/users", 7) }, lp[0] + "served path=/users n=7"},
+		{"escalate lower", func(e *Event) { e.Escalate(true, LevelDebug).Emit("/users", 7) }, lp[0] + "served path=/users n=7"},
+		{"missing", func(e *Event) { e.Emit("/users") }, lp[0] + "served path=/users n=<missing>"},
+		{"extra", func(e *Event) { e.Emit("/users", 7, "a", 8) }, lp[0] + "served path=/users n=7 extra=[a 8]"},
+		{"disabled", func(e *Event) { e.Log(LevelDebug, "/users", 7) }, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			tc.log(l.Event(LevelInfo, "served", "path", "n"))
+
+			pattern := "^$"
+			if tc.want != "" {
+				pattern = ts + regexp.QuoteMeta(tc.want) + "\n$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestEventCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+	l.Event(LevelInfo, "served", "n").Emit(1)
+
+	SetWriter(w)
+	defer SetWriter(os.Stdout)
+	Verbose(true)
+	defer Verbose(false)
+	NewEvent(LevelWarning, "served", "n").Emit(2)
+
+	line := ts[1:] + "event_test.go:[0-9]+: "
+	pattern := "^" + line + regexp.QuoteMeta(lp[0]+"served n=1") + "\n" +
+		line + regexp.QuoteMeta(lp[1]+"served n=2") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExemplarSink is a RecordSink writing the records holding a trace ID (see
+// SetTraceExtractor) and a numeric value field as OpenMetrics samples with an
+// exemplar, e.g. for
+//
+//	l.AddRecordSink(log.NewExemplarSink(w, "http_request_duration_seconds", "latency"))
+//	l.With("latency", 670*time.Millisecond).InfoContext(ctx, "served")
+//
+// the line
+//
+//	http_request_duration_seconds 0.67 # {trace_id="4bf92f35",span_id="00f067aa"} 0.67 1520879607.789
+//
+// linking the observation to its trace. A time.Duration value is written in
+// seconds. The other records are left out.
+// An ExemplarSink is safe for concurrent use.
+type ExemplarSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	metric string
+	key    string
+}
+
+// NewExemplarSink returns an ExemplarSink writing to w the samples of the
+// given metric name from the values of the field key.
+func NewExemplarSink(w io.Writer, metric, key string) *ExemplarSink {
+	return &ExemplarSink{w: w, metric: metric, key: key}
+}
+
+// WriteRecord implements RecordSink.
+func (e *ExemplarSink) WriteRecord(r Record) error {
+	var traceID, spanID string
+	var value float64
+	var ok bool
+	for _, f := range r.Fields {
+		switch f.Key {
+		case TraceIDKey:
+			traceID = fmt.Sprint(f.Value)
+		case SpanIDKey:
+			spanID = fmt.Sprint(f.Value)
+		case e.key:
+			value, ok = number(f.Value)
+		}
+	}
+	if traceID == "" || !ok {
+		return nil
+	}
+
+	v := strconv.FormatFloat(value, 'g', -1, 64)
+	b := make([]byte, 0, 128)
+	b = append(b, e.metric...)
+	b = append(b, ' ')
+	b = append(b, v...)
+	b = append(b, ` # {trace_id=`...)
+	b = appendLabelValue(b, traceID)
+	if spanID != "" {
+		b = append(b, `,span_id=`...)
+		b = appendLabelValue(b, spanID)
+	}
+	b = append(b, "} "...)
+	b = append(b, v...)
+	if !r.Time.IsZero() {
+		b = append(b, ' ')
+		b = strconv.AppendFloat(b, float64(r.Time.UnixMilli())/1e3, 'f', 3, 64)
+	}
+	b = append(b, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, err := e.w.Write(b)
+	return err
+}
+
+// labelEscaper escapes the OpenMetrics label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// appendLabelValue appends s as a quoted OpenMetrics label value.
+func appendLabelValue(b []byte, s string) []byte {
+	b = append(b, '"')
+	b = append(b, labelEscaper.Replace(s)...)
+	return append(b, '"')
+}
+
+// number returns v as a float64, reporting whether it is a number, a
+// time.Duration being converted to seconds.
+func number(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case time.Duration:
+		return x.Seconds(), true
+	case int:
+		return float64(x), true
+	case int8:
+		return float64(x), true
+	case int16:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint:
+		return float64(x), true
+	case uint8:
+		return float64(x), true
+	case uint16:
+		return float64(x), true
+	case uint32:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case float32:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
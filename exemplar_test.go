@@ -0,0 +1,51 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestExemplarSink(t *testing.T) {
+	tt := []struct {
+		name    string
+		traceID string
+		spanID  string
+		value   interface{}
+		want    string
+	}{
+		{"duration", "4bf92f35", "00f067aa", 670 * time.Millisecond, `latency_seconds 0.67 # {trace_id="4bf92f35",span_id="00f067aa"} 0.67 `},
+		{"int", "4bf92f35", "", 3, `latency_seconds 3 # {trace_id="4bf92f35"} 3 `},
+		{"escaped", `a"b\`, "", 1.5, `latency_seconds 1.5 # {trace_id="a\"b\\"} 1.5 `},
+		{"no trace", "", "", 3, ""},
+		{"not a number", "4bf92f35", "", "3", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(io.Discard))
+			l.SetTraceExtractor(func(context.Context) (string, string) { return tc.traceID, tc.spanID })
+			l.AddRecordSink(NewExemplarSink(w, "latency_seconds", "latency"))
+			l.With("latency", tc.value).InfoContext(context.Background(), "served")
+
+			got := w.String()
+			if tc.want == "" {
+				if got != "" {
+					t.Errorf("want nothing, got %q", got)
+				}
+				return
+			}
+			if len(got) < len(tc.want) || got[:len(tc.want)] != tc.want {
+				t.Fatalf("want prefix %q, got %q", tc.want, got)
+			}
+			var sec float64
+			if _, err := fmt.Sscanf(got[len(tc.want):], "%f\n", &sec); err != nil || time.Since(time.UnixMilli(int64(sec*1e3))) > time.Minute {
+				t.Errorf("bad timestamp in %q: %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Field is a key/value pair attached to every message of a logger, see With.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// With returns a child of the standard logger carrying the given fields,
+// see Logger.With.
+func With(keys ...interface{}) *Logger {
+	return std.With(keys...)
+}
+
+// With returns a child logger appending the given key/value pairs to every
+// message as " key=value", after the text and sorted by key.
+// A key given again replaces the inherited value; a key without a value gets
+// "<missing>".
+// The child shares the writers of l (see SetWriter), its lines being
+// serialized with those of l, and starts with a copy of its other settings:
+// later changes to them, such as SetTimestamp, do not affect the other logger.
+func (l *Logger) With(keys ...interface{}) *Logger {
+	c := l.clone()
+	c.fields = withFields(c.fields, keys)
+	c.fieldText = fieldsText(c.limitFields(c.fields))
+	return c
+}
+
+// SetGlobalFields sets key/value pairs, such as the service name and
+// version, appended to every message of the standard logger and of the
+// loggers derived from it afterwards (see With), replacing those previously
+// set. Fields with the same key given to With or to the message, as with
+// Infow, take precedence.
+func SetGlobalFields(keys ...interface{}) {
+	fields := withFields(nil, keys)
+
+	std.omu.Lock()
+	defer std.omu.Unlock()
+
+	std.fields = fields
+	std.fieldText = fieldsText(std.limitFields(fields))
+}
+
+// withFields returns a copy of fields with the given key/value pairs set,
+// sorted by key, see With.
+func withFields(fields []Field, keys []interface{}) []Field {
+	fields = append([]Field(nil), fields...)
+	for i := 0; i < len(keys); i += 2 {
+		f := Field{Key: fmt.Sprint(keys[i]), Value: "<missing>"}
+		if i+1 < len(keys) {
+			f.Value = keys[i+1]
+		}
+		fields = setField(fields, f)
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+	return fields
+}
+
+// SetFieldTransformer sets the field transformer of the standard logger,
+// see Logger.SetFieldTransformer.
+func SetFieldTransformer(fn func(key string, value interface{}) (string, interface{})) {
+	std.SetFieldTransformer(fn)
+}
+
+// SetFieldTransformer sets a function called on every field of a message,
+// the logger ones (see With) and the message ones (see Infow and the
+// Context methods), before it is rendered, in the manner of the ReplaceAttr
+// option of log/slog: it returns the key and value to render, or an empty
+// key to drop the field. The resource labels are left alone.
+// A nil fn disables it (default).
+func (l *Logger) SetFieldTransformer(fn func(key string, value interface{}) (string, interface{})) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.fieldFn = fn
+}
+
+// transformFields returns the logger fields base followed by extra, run
+// through the field transformer, requiring l.omu to be held.
+func (l *Logger) transformFields(base, extra []Field) []Field {
+	fields := make([]Field, 0, len(base)+len(extra))
+	for _, group := range [][]Field{base, extra} {
+		for _, f := range group {
+			if k, v := l.fieldFn(f.Key, f.Value); k != "" {
+				fields = append(fields, Field{Key: k, Value: v})
+			}
+		}
+	}
+	return fields
+}
+
+// fieldsText renders fields as " key=value" pairs.
+func fieldsText(fields []Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	kv := make([]interface{}, 0, 2*len(fields))
+	for _, f := range fields {
+		kv = append(kv, f.Key, f.Value)
+	}
+	return formatFields(kv)
+}
+
+// FieldPosition selects where the fields are printed, see SetFieldPosition.
+type FieldPosition int
+
+// Available field positions.
+const (
+	FieldsAfter  FieldPosition = iota // After the message (default)
+	FieldsBefore                      // Before the message
+)
+
+// SetFieldPosition sets where the standard logger prints the fields, see
+// Logger.SetFieldPosition.
+func SetFieldPosition(pos FieldPosition) {
+	std.SetFieldPosition(pos)
+}
+
+// SetFieldPosition sets whether the default output prints the " key=value"
+// fields, resource labels included, after the message (default) or before
+// it, as in "INFO> key=value message", for parsers expecting them first.
+// A Formatter, such as JSONFormatter, is not affected.
+func (l *Logger) SetFieldPosition(pos FieldPosition) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.fieldPos = pos
+}
+
+// appendBody appends to b the message s, without trailing newline, and the
+// rendered fields followed by the resource labels, in the order set by
+// SetFieldPosition. It requires l.omu to be held.
+func (l *Logger) appendBody(b []byte, s, fieldText string) []byte {
+	s = strings.TrimSuffix(s, "\n")
+	fieldText += resourceFields()
+	if l.fieldPos == FieldsBefore && fieldText != "" {
+		b = append(b, fieldText[1:]...)
+		if s != "" {
+			b = append(b, ' ')
+		}
+		return append(b, s...)
+	}
+	b = append(b, s...)
+	return append(b, fieldText...)
+}
+
+// dropFields returns fields without those having the key of one in extra,
+// fields itself if there is none.
+func dropFields(fields, extra []Field) []Field {
+	var kept []Field
+	for i, f := range fields {
+		drop := false
+		for _, e := range extra {
+			if e.Key == f.Key {
+				drop = true
+				break
+			}
+		}
+		switch {
+		case drop && kept == nil:
+			kept = append(make([]Field, 0, len(fields)-1), fields[:i]...)
+		case !drop && kept != nil:
+			kept = append(kept, f)
+		}
+	}
+	if kept == nil {
+		return fields
+	}
+	return kept
+}
+
+// setField replaces the field with the key of f, or appends f.
+func setField(fields []Field, f Field) []Field {
+	for i := range fields {
+		if fields[i].Key == f.Key {
+			fields[i] = f
+			return fields
+		}
+	}
+	return append(fields, f)
+}
+
+// Clone returns a new logger with a copy of the settings of l, see
+// Logger.Clone.
+func Clone() *Logger {
+	return std.Clone()
+}
+
+// Clone returns a new logger with a copy of the settings of l: level, caller
+// reporting, timestamp, line wrap, level labels, tag, colors, fields,
+// formatter, hooks and the like, as well as the writers. Later changes to
+// either logger, SetWriter included, do not affect the other; their lines
+// are still serialized, as they may go to the same writer.
+// The clone shares with l the syslog connection (see SetSyslog) and the
+// context extractor; it gets neither the heartbeats, the aggregates, the
+// level change callbacks nor the asynchronous or paused output of l, and it
+// uses the default call depth (see SetCallDepth) as its methods are called
+// directly.
+func (l *Logger) Clone() *Logger {
+	c := l.clone()
+
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	c.out = newOutput(l.writer())
+	if l.errOut != nil {
+		c.errOut = newOutput(l.errorWriter())
+	}
+	if l.levelOut != nil {
+		c.levelOut = make(map[Severity]*output, len(l.levelOut))
+		for level, o := range l.levelOut {
+			c.levelOut[level] = newOutput(o.Writer())
+		}
+	}
+	return c
+}
+
+// clone returns a new logger sharing the outputs and the output lock of l,
+// with a copy of its settings.
+func (l *Logger) clone() *Logger {
+	c := New(l.Level())
+	c.out = l.out
+	c.callerMin.Store(l.callerMin.Load())
+	c.defLevel.Store(l.defLevel.Load())
+	c.maxDepth.Store(l.maxDepth.Load())
+
+	l.mu.Lock()
+	c.flags = l.flags
+	c.hdrFlags.Store(l.hdrFlags.Load())
+	c.fatalPolicy = l.fatalPolicy
+	c.ctxFields = l.ctxFields
+	c.traceIDs = l.traceIDs
+	c.omitted = l.omitted
+	c.errFormat = l.errFormat
+	c.formatter = l.formatter
+	c.aggLevel = l.aggLevel
+	c.timeFormat = l.timeFormat
+	c.customTime = l.customTime
+	l.mu.Unlock()
+
+	l.omu.Lock()
+	c.fields = l.fields
+	c.fieldText = l.fieldText
+	c.errOut = l.errOut
+	c.errMin = l.errMin
+	c.levelOut = l.levelOut
+	c.samplers = l.samplers
+	c.linePrefix = l.linePrefix
+	c.suffix = l.suffix
+	c.newline = l.newline
+	c.escapeNL = l.escapeNL
+	c.lineID = l.lineID
+	c.skipEmpty = l.skipEmpty
+	c.fieldPos = l.fieldPos
+	c.maxLen = l.maxLen
+	c.pauseMax = l.pauseMax
+	c.color = l.color
+	c.prefixes = l.prefixes
+	c.tag = l.tag
+	c.sys = l.sys
+	c.hooks = l.hooks
+	c.recSinks = l.recSinks
+	c.ring = l.ring
+	c.redactors = l.redactors
+	c.fieldFn = l.fieldFn
+	l.omu.Unlock()
+	c.omu = l.omu
+	return c
+}
@@ -0,0 +1,354 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+		want string
+	}{
+		{"none", func(l *Logger) { l.With().Info("Ciao") }, lp[0] + "Ciao"},
+		{"sorted", func(l *Logger) { l.With("req", 7, "id", "x").Info("Ciao") }, lp[0] + "Ciao id=x req=7"},
+		{"missing value", func(l *Logger) { l.With("id").Warning("Ciao") }, lp[1] + "Ciao id=<missing>"},
+		{"nested", func(l *Logger) { l.With("b", 2).With("a", 1).With("c", 3).Error("Ciao") }, lp[2] + "Ciao a=1 b=2 c=3"},
+		{"nested replace", func(l *Logger) { l.With("a", 1, "b", 2).With("a", 3).Info("Ciao") }, lp[0] + "Ciao a=3 b=2"},
+		{"trailing newline", func(l *Logger) { l.With("a", 1).Infoln("Ciao") }, lp[0] + "Ciao a=1"},
+		{"before suffix", func(l *Logger) { l.SetLineWrap("", " <<<"); l.With("a", 1).Info("Ciao") }, lp[0] + "Ciao a=1 <<<"},
+		{"parent unchanged", func(l *Logger) { l.With("a", 1); l.Info("Ciao") }, lp[0] + "Ciao"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			tc.f(l)
+
+			pattern := ts + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestWithIndependent(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	parent := l.With("a", 1)
+	child := parent.With("b", 2)
+	parent.With("c", 3) // must not leak into child
+	parent.SetLevel(LevelError)
+	child.Info("Ciao")
+	parent.Info("Ciao")
+
+	pattern := ts + regexp.QuoteMeta(lp[0]+"Ciao a=1 b=2") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestWithPackageLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	SetCallerMinLevel(LevelInfo)
+	defer SetCallerMinLevel(LevelOff)
+	With("a", 1).Info("Ciao")
+
+	pattern := ts + "fields_test.go:[0-9]+: " + regexp.QuoteMeta(lp[0]+"Ciao a=1") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestSetFieldTransformer(t *testing.T) {
+	rename := func(k string, v interface{}) (string, interface{}) {
+		if k == "id" {
+			return "request_id", v
+		}
+		return k, v
+	}
+	drop := func(k string, v interface{}) (string, interface{}) {
+		if k == "password" {
+			return "", nil
+		}
+		return k, v
+	}
+	mask := func(k string, v interface{}) (string, interface{}) {
+		if k == "password" {
+			return k, "****"
+		}
+		return k, v
+	}
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+		want string
+	}{
+		{"rename", func(l *Logger) { l.SetFieldTransformer(rename); l.With("id", 7).Info("Ciao") }, lp[0] + "Ciao request_id=7"},
+		{"drop", func(l *Logger) { l.SetFieldTransformer(drop); l.Infow("Ciao", "user", "bob", "password", "x") }, lp[0] + "Ciao user=bob"},
+		{"value", func(l *Logger) { l.SetFieldTransformer(mask); l.With("password", "x").Info("Ciao") }, lp[0] + "Ciao password=****"},
+		{"logger and message fields", func(l *Logger) {
+			l.SetFieldTransformer(mask)
+			l.With("a", 1).Warnw("Ciao", "password", "x")
+		}, lp[1] + "Ciao a=1 password=****"},
+		{"JSON", func(l *Logger) {
+			l.SetTimestamp(false)
+			l.SetFormatter(JSONFormatter{})
+			l.SetFieldTransformer(rename)
+			l.With("id", 7).Info("Ciao")
+		}, `{"level":"info","msg":"Ciao","request_id":7}`},
+		{"disabled", func(l *Logger) {
+			l.SetFieldTransformer(drop)
+			l.SetFieldTransformer(nil)
+			l.With("password", "x").Info("Ciao")
+		}, lp[0] + "Ciao password=x"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			tc.f(l)
+
+			pattern := regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(c *Logger)
+	}{
+		{"level", func(c *Logger) { c.SetLevel(LevelError) }},
+		{"writer", func(c *Logger) { c.SetWriter(new(bytes.Buffer)) }},
+		{"add writer", func(c *Logger) { c.AddWriter(new(bytes.Buffer)) }},
+		{"error writer", func(c *Logger) { c.SetErrorWriter(new(bytes.Buffer)) }},
+		{"level writer", func(c *Logger) { c.SetLevelWriter(LevelInfo, new(bytes.Buffer)) }},
+		{"verbose", func(c *Logger) { c.Verbose(true) }},
+		{"caller", func(c *Logger) { c.SetCaller(CallerLong) }},
+		{"caller min level", func(c *Logger) { c.SetCallerMinLevel(LevelTrace) }},
+		{"timestamp", func(c *Logger) { c.SetTimestamp(false) }},
+		{"microseconds", func(c *Logger) { c.SetMicroseconds(false) }},
+		{"time format", func(c *Logger) { c.SetTimeFormat("15:04") }},
+		{"UTC", func(c *Logger) { c.SetUTC(true) }},
+		{"line wrap", func(c *Logger) { c.SetLineWrap("<", ">") }},
+		{"prefix", func(c *Logger) { c.SetPrefix(LevelInfo, "I ") }},
+		{"tag", func(c *Logger) { c.SetTag("[c] ") }},
+		{"color", func(c *Logger) { c.SetColor(ColorAlways) }},
+		{"newline", func(c *Logger) { c.SetNewline("") }},
+		{"escape newlines", func(c *Logger) { c.SetEscapeNewlines(true) }},
+		{"skip empty", func(c *Logger) { c.SetSkipEmpty(true) }},
+		{"max message length", func(c *Logger) { c.SetMaxMessageLength(1) }},
+		{"line ID", func(c *Logger) { c.SetLineID(true) }},
+		{"default level", func(c *Logger) { c.SetDefaultLevel(LevelError) }},
+		{"formatter", func(c *Logger) { c.SetFormatter(JSONFormatter{}) }},
+		{"hook", func(c *Logger) { c.AddHook(funcHook(func(Severity, string) { panic("hook") })) }},
+		{"field transformer", func(c *Logger) {
+			c.SetFieldTransformer(func(string, interface{}) (string, interface{}) { return "", nil })
+		}},
+	}
+
+	run := func(l *Logger) {
+		l.Info("Ciao")
+		l.Info()
+		l.Print("Ciao")
+		l.Infow("Ciao", "a", 1)
+		l.Error("Ciao")
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, ew := new(bytes.Buffer), new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w), WithErrorWriter(ew))
+			run(l)
+			want, ewant := w.String(), ew.String()
+			w.Reset()
+			ew.Reset()
+
+			tc.f(l.Clone())
+			run(l)
+
+			sameLines(t, stdFlags, w.String(), want)
+			sameLines(t, stdFlags, ew.String(), ewant)
+		})
+	}
+}
+
+func TestCloneSettings(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelDebug, WithWriter(new(bytes.Buffer)), WithVerbose(true))
+	l.SetLineWrap(">> ", "")
+	l.SetTag("[db] ")
+	c := l.With("a", 1).Clone()
+	l.SetWriter(io.Discard)
+	c.SetWriter(w)
+	c.Debug("Ciao")
+
+	pattern := "^>> " + ts[1:] + "fields_test.go:[0-9]+: " + regexp.QuoteMeta("[db] DEBUG> Ciao a=1") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestWithOwnHeader(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(c *Logger)
+	}{
+		{"timestamp", func(c *Logger) { c.SetTimestamp(false) }},
+		{"line wrap", func(c *Logger) { c.SetLineWrap(">> ", " <<") }},
+		{"UTC", func(c *Logger) { c.SetUTC(true) }},
+		{"caller", func(c *Logger) { c.SetCaller(CallerLong) }},
+		{"time format", func(c *Logger) { c.SetTimeFormat("15:04") }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.Info("Ciao")
+			want := w.String()
+			w.Reset()
+
+			tc.f(l.With("a", 1))
+			l.Info("Ciao")
+
+			sameLines(t, stdFlags, w.String(), want)
+		})
+	}
+}
+
+func TestWithSharedLock(t *testing.T) {
+	w := bufio.NewWriter(io.Discard)
+	l := New(LevelInfo, WithWriter(w))
+	c := l.With("a", 1)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.Info("Ciao")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l.Flush() // #nosec
+		}
+	}()
+	wg.Wait()
+}
+
+func TestWithTableContiguous(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetTimestamp(false)
+	c := l.With("a", 1)
+	rows := map[string]string{"k1": "v", "k2": "v", "k3": "v", "k4": "v"}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.Info("Ciao")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l.Table(LevelInfo, rows)
+		}
+	}()
+	wg.Wait()
+
+	block := lp[0] + "k1  v\n" + lp[0] + "k2  v\n" + lp[0] + "k3  v\n" + lp[0] + "k4  v\n"
+	rest := strings.ReplaceAll(w.String(), block, "")
+	if got := strings.Count(rest, "k"); got != 0 {
+		t.Errorf("table rows interleaved with other lines: %q", rest)
+	}
+}
+
+func TestFieldPosition(t *testing.T) {
+	defer SetResourceLabels(nil)
+
+	tt := []struct {
+		name string
+		pos  FieldPosition
+		f    func(l *Logger)
+		want string
+	}{
+		{"after", FieldsAfter, func(l *Logger) { l.With("a", 1).Infow("Ciao", "b", 2) }, lp[0] + "Ciao a=1 b=2 pod=web-1\n"},
+		{"before", FieldsBefore, func(l *Logger) { l.With("a", 1).Infow("Ciao", "b", 2) }, lp[0] + "a=1 b=2 pod=web-1 Ciao\n"},
+		{"before empty message", FieldsBefore, func(l *Logger) { l.With("a", 1).Info() }, lp[0] + "a=1 pod=web-1\n"},
+		{"before JSON", FieldsBefore, func(l *Logger) { l.SetFormatter(JSONFormatter{}); l.With("a", 1).Info("Ciao") }, `{"level":"info","msg":"Ciao","a":1,"resource":{"pod":"web-1"}}` + "\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetTimestamp(false)
+			l.SetFieldPosition(tc.pos)
+			SetResourceLabels(map[string]string{"pod": "web-1"})
+			tc.f(l)
+
+			if w.String() != tc.want {
+				t.Errorf("mismatch! Want %q, got %q", tc.want, w.String())
+			}
+		})
+	}
+
+	l := New(LevelInfo, WithWriter(new(bytes.Buffer)))
+	l.SetFieldPosition(FieldsBefore)
+	SetResourceLabels(nil)
+	if got, want := l.With("a", 1).Sprintf(LevelInfo, "Ciao"), lp[0]+"a=1 Ciao"; got != want {
+		t.Errorf("Sprintf: want %q, got %q", want, got)
+	}
+}
+
+func TestSetGlobalFields(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	defer SetWriter(os.Stdout)
+	SetTimestamp(false)
+	defer SetTimestamp(true)
+	SetGlobalFields("service", "api", "env", "prod")
+	defer SetGlobalFields()
+
+	Info("Ciao")
+	With("env", "dev").Info("Ciao")
+	Infow("Ciao", "service", "worker")
+	With("a", 1).Infow("Ciao", "a", 2)
+	SetGlobalFields("version", 3)
+	Info("Ciao")
+
+	want := lp[0] + "Ciao env=prod service=api\n" +
+		lp[0] + "Ciao env=dev service=api\n" +
+		lp[0] + "Ciao env=prod service=worker\n" +
+		lp[0] + "Ciao env=prod service=api a=2\n" +
+		lp[0] + "Ciao version=3\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+	if l := New(LevelInfo, WithWriter(w)); len(l.fields) != 0 {
+		t.Errorf("global fields on a new logger: %v", l.fields)
+	}
+}
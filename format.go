@@ -0,0 +1,226 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Formatter renders a message into a line, without the trailing newline.
+// ts is zero when the timestamp is disabled. fields holds the level name
+// under LevelKey if the logger renamed the level, the caller, if reported,
+// under CallerKey and FuncKey, then the logger fields, the message fields
+// and the resource labels under ResourceKey.
+type Formatter interface {
+	Format(level Severity, ts time.Time, msg string, fields []Field) ([]byte, error)
+}
+
+// Keys of the fields carrying the caller and the resource labels,
+// see Formatter.
+const (
+	CallerKey   = "caller"   // the "file:line" of the caller
+	FuncKey     = "func"     // the function of the caller, such as "main.run"
+	ResourceKey = "resource" // the labels set by SetResourceLabels, a map[string]string
+	LevelKey    = "level"    // the level name set by Logger.SetPrefix, if any
+)
+
+// levelName is the value of the LevelKey field, telling it apart from a
+// message field with the same key.
+type levelName string
+
+// renamedLevel returns the name given to level by fields, if any.
+func renamedLevel(fields []Field) (string, bool) {
+	if len(fields) == 0 || fields[0].Key != LevelKey {
+		return "", false
+	}
+	name, ok := fields[0].Value.(levelName)
+	return string(name), ok
+}
+
+// TextFormatter renders messages in the default layout:
+//
+//	2009/01/23 01:23:23.123123 file.go:23: INFO> message key=value
+//
+// A level renamed by Logger.SetPrefix is labeled with its name, as "NOTICE> ".
+type TextFormatter struct {
+	// TimeFormat is the layout of the timestamp,
+	// "2006/01/02 15:04:05.000000" if empty.
+	TimeFormat string
+}
+
+// Format implements Formatter.
+func (f TextFormatter) Format(level Severity, ts time.Time, msg string, fields []Field) ([]byte, error) {
+	var b bytes.Buffer
+	if !ts.IsZero() {
+		layout := f.TimeFormat
+		if layout == "" {
+			layout = "2006/01/02 15:04:05.000000"
+		}
+		b.WriteString(ts.Format(layout))
+		b.WriteByte(' ')
+	}
+	for _, field := range fields {
+		if field.Key == CallerKey {
+			fmt.Fprintf(&b, "%v: ", field.Value)
+		}
+	}
+	label := prefix[level]
+	if name, ok := renamedLevel(fields); ok {
+		label = name + "> "
+		fields = fields[1:]
+	}
+	b.WriteString(label)
+	b.WriteString(strings.TrimSuffix(msg, "\n"))
+	for _, field := range fields {
+		if labels, ok := resourceLabelsOf(field); ok {
+			keys := make([]string, 0, len(labels))
+			for k := range labels {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fmt.Fprintf(&b, " %s=%v", k, labels[k])
+			}
+			continue
+		}
+		if field.Key != CallerKey && field.Key != FuncKey {
+			fmt.Fprintf(&b, " %s=%v", field.Key, field.Value)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// JSONFormatter renders messages as JSON objects, one per line:
+//
+//	{"level":"info","ts":"2009-01-23T01:23:23.123123+01:00","msg":"message","key":"value"}
+//
+// The timestamp is in RFC 3339 format. Field values are encoded with
+// encoding/json, falling back to their fmt.Sprint text. A field whose key is
+// taken, such as "msg" or a key given twice, is renamed "fields.msg".
+// The resource labels are nested in a "resource" object, last. The level is
+// the lower case name set by Logger.SetPrefix, if any, or Severity.String.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(level Severity, ts time.Time, msg string, fields []Field) ([]byte, error) {
+	name := level.String()
+	if n, ok := renamedLevel(fields); ok {
+		name, fields = n, fields[1:]
+	}
+	var b bytes.Buffer
+	b.WriteString(`{"level":`)
+	writeJSON(&b, strings.ToLower(name))
+	if !ts.IsZero() {
+		b.WriteString(`,"ts":`)
+		writeJSON(&b, ts.Format(time.RFC3339Nano))
+	}
+	b.WriteString(`,"msg":`)
+	writeJSON(&b, strings.TrimSuffix(msg, "\n"))
+	taken := map[string]bool{"level": true, "ts": true, "msg": true}
+	var resource Field
+	for _, field := range fields {
+		if _, ok := resourceLabelsOf(field); ok {
+			resource, taken[ResourceKey] = field, true
+		}
+	}
+	for _, field := range fields {
+		if _, ok := resourceLabelsOf(field); ok {
+			continue
+		}
+		key := field.Key
+		for taken[key] {
+			key = "fields." + key
+		}
+		taken[key] = true
+		b.WriteByte(',')
+		writeJSON(&b, key)
+		b.WriteByte(':')
+		writeJSON(&b, field.Value)
+	}
+	if resource.Value != nil {
+		b.WriteString(`,"resource":`)
+		writeJSON(&b, resource.Value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// resourceLabelsOf returns the labels of field if it is the ResourceKey field.
+func resourceLabelsOf(field Field) (map[string]string, bool) {
+	if field.Key != ResourceKey {
+		return nil, false
+	}
+	labels, ok := field.Value.(map[string]string)
+	return labels, ok
+}
+
+// writeJSON writes the JSON encoding of v, or of its fmt.Sprint text if v
+// cannot be encoded.
+func writeJSON(b *bytes.Buffer, v interface{}) {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	p, err := json.Marshal(v)
+	if err != nil {
+		p, _ = json.Marshal(fmt.Sprint(v)) // #nosec
+	}
+	b.Write(p)
+}
+
+// SetFormatter sets the formatter of the standard logger, see Logger.SetFormatter.
+func SetFormatter(f Formatter) {
+	std.SetFormatter(f)
+}
+
+// SetFormatter sets the formatter rendering every message, e.g. JSONFormatter.
+// The formatter takes over the timestamp, honoring SetTimestamp and SetUTC,
+// and the caller reporting. A nil f restores the default output.
+// Should the formatter fail, the message is rendered by TextFormatter.
+func (l *Logger) SetFormatter(f Formatter) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.formatter = f
+	l.applyFlags()
+}
+
+// format renders s with the formatter and the logger and message fields,
+// requiring l.omu to be held.
+// calldepth and pc locate the caller as for caller, counted from format.
+func (l *Logger) format(calldepth int, pc uintptr, level Severity, s string, msgFields []Field, first bool) string {
+	l.mu.Lock()
+	flags := l.flags
+	l.mu.Unlock()
+
+	var ts time.Time
+	if l.customTime && l.timeFormat != "" || !l.customTime && flags&(log.Ldate|log.Ltime) != 0 {
+		ts = time.Now()
+		if flags&log.LUTC != 0 {
+			ts = ts.UTC()
+		}
+	}
+	fields := make([]Field, 0, 3+len(msgFields)+len(resourceList()))
+	if name, ok := l.levelName(level); ok {
+		fields = append(fields, Field{Key: LevelKey, Value: levelName(name)})
+	}
+	if first || l.callerAt(level) || flags&(log.Lshortfile|log.Llongfile) != 0 {
+		c := frameCaller(callerFrame(calldepth, pc))
+		fields = append(fields,
+			Field{Key: CallerKey, Value: c.location(flags&log.Llongfile != 0)},
+			Field{Key: FuncKey, Value: c.Func})
+	}
+	fields = append(fields, l.limitFields(msgFields)...)
+	fields = append(fields, resourceList()...)
+
+	b, err := l.formatter.Format(level, ts, s, fields)
+	if err != nil {
+		b, _ = TextFormatter{}.Format(level, ts, s, fields) // #nosec
+	}
+	return string(b) + l.suffix
+}
@@ -0,0 +1,184 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextFormatter(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+		want string
+	}{
+		{"plain", func(l *Logger) { l.Info("Ciao") }, ts + regexp.QuoteMeta(lp[0]+"Ciao")},
+		{"fields", func(l *Logger) { l.With("b", 2, "a", 1).Warningln("Ciao") }, ts + regexp.QuoteMeta(lp[1]+"Ciao a=1 b=2")},
+		{"caller", func(l *Logger) { l.Verbose(true); l.Error("Ciao") }, ts + "format_test.go:[0-9]+: " + regexp.QuoteMeta(lp[2]+"Ciao")},
+		{"no timestamp", func(l *Logger) { l.SetTimestamp(false); l.Info("Ciao") }, "^" + regexp.QuoteMeta(lp[0]+"Ciao")},
+		{"no time format", func(l *Logger) { l.SetTimeFormat(""); l.Info("Ciao") }, "^" + regexp.QuoteMeta(lp[0]+"Ciao")},
+		{"line wrap", func(l *Logger) { l.SetLineWrap(">>> ", " <<<"); l.Info("Ciao") }, "^>>> [0-9/]{10} [0-9:.]{15} " + regexp.QuoteMeta(lp[0]+"Ciao <<<")},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetFormatter(TextFormatter{})
+			tc.f(l)
+
+			pattern := tc.want + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	defer SetResourceLabels(nil)
+	SetResourceLabels(map[string]string{"pod": "web-1"})
+
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetFormatter(JSONFormatter{})
+	l.SetCallerMinLevel(LevelWarning)
+	l.With("req", 7, "err", errors.New("boom")).Warningf("Ciao %q", "you")
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatalf("unable to unmarshal %q: %v", w.String(), err)
+	}
+	want := map[string]interface{}{
+		"level": "warn",
+		"msg":   `Ciao "you"`,
+		"req":   7.0,
+		"err":   "boom",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: want %v, got %v", k, v, got[k])
+		}
+	}
+	if r, want := got[ResourceKey], map[string]interface{}{"pod": "web-1"}; !reflect.DeepEqual(r, want) {
+		t.Errorf("resource: want %v, got %v", want, r)
+	}
+	if c, _ := got[CallerKey].(string); !regexp.MustCompile(`^format_test.go:[0-9]+$`).MatchString(c) {
+		t.Errorf("caller: want format_test.go:line, got %v", got[CallerKey])
+	}
+	if f := got[FuncKey]; f != "log.TestJSONFormatter" {
+		t.Errorf("func: want log.TestJSONFormatter, got %v", f)
+	}
+	ts, _ := got["ts"].(string)
+	if d, err := time.Parse(time.RFC3339Nano, ts); err != nil || time.Since(d) > time.Minute {
+		t.Errorf("ts: want a recent RFC 3339 timestamp, got %q", ts)
+	}
+}
+
+func TestJSONFormatterCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetFormatter(JSONFormatter{})
+	l.SetTimestamp(false)
+	l.Info("Ciao")
+	l.Verbose(true)
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("Ciao")
+	l.SetCallDepth(l.CallDepth() + 1)
+	infoHelper(l, "Ciao")
+
+	want := `{"level":"info","msg":"Ciao"}` + "\n" +
+		`{"level":"info","msg":"Ciao","caller":"format_test.go:` + strconv.Itoa(line+1) + `","func":"log.TestJSONFormatterCaller"}` + "\n" +
+		`{"level":"info","msg":"Ciao","caller":"format_test.go:` + strconv.Itoa(line+3) + `","func":"log.TestJSONFormatterCaller"}` + "\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+}
+
+func TestJSONFormatterKeyClash(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+		want string
+	}{
+		{"msg", func(l *Logger) { l.Infow("hi", "msg", "dup") }, `{"level":"info","msg":"hi","fields.msg":"dup"}`},
+		{"level and ts", func(l *Logger) { l.With("level", 1, "ts", 2).Info("hi") }, `{"level":"info","msg":"hi","fields.level":1,"fields.ts":2}`},
+		{"twice", func(l *Logger) { l.Infow("hi", "a", 1, "a", 2, "fields.a", 3) }, `{"level":"info","msg":"hi","a":1,"fields.a":2,"fields.fields.a":3}`},
+		{"caller", func(l *Logger) { l.SetCaller(CallerShort); l.Infow("hi", "caller", "me") }, `"caller":"format_test.go:`},
+		{"caller renamed", func(l *Logger) { l.SetCaller(CallerShort); l.Infow("hi", "caller", "me") }, `,"fields.caller":"me"}`},
+		{"caller not reported", func(l *Logger) { l.Infow("hi", "caller", "me") }, `{"level":"info","msg":"hi","caller":"me"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetFormatter(JSONFormatter{})
+			l.SetTimestamp(false)
+			tc.f(l)
+
+			if !strings.Contains(w.String(), tc.want) {
+				t.Errorf("mismatch! Want %q in %q", tc.want, w.String())
+			}
+			var v map[string]interface{}
+			if err := json.Unmarshal(w.Bytes(), &v); err != nil {
+				t.Errorf("unable to unmarshal %q: %v", w.String(), err)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterNoTimestamp(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetFormatter(JSONFormatter{})
+	l.SetTimestamp(false)
+	l.Info("Ciao")
+
+	if want := `{"level":"info","msg":"Ciao"}` + "\n"; w.String() != want {
+		t.Errorf("want %q, got %q", want, w.String())
+	}
+}
+
+type failingFormatter struct{}
+
+func (failingFormatter) Format(Severity, time.Time, string, []Field) ([]byte, error) {
+	return nil, errors.New("boom")
+}
+
+func TestFormatterFallback(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetFormatter(failingFormatter{})
+	l.Info("Ciao")
+
+	pattern := ts + regexp.QuoteMeta(lp[0]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestSetFormatterNil(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	SetFormatter(JSONFormatter{})
+	SetFormatter(nil)
+	Info("Ciao")
+
+	pattern := ts + regexp.QuoteMeta(lp[0]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
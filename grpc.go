@@ -0,0 +1,37 @@
+package log
+
+// GRPCLogger is the LoggerV2 interface of google.golang.org/grpc/grpclog,
+// declared here so as not to depend on gRPC: a GRPCLogger can be passed to
+// grpclog.SetLoggerV2 as is.
+type GRPCLogger interface {
+	Info(args ...interface{})
+	Infoln(args ...interface{})
+	Infof(format string, args ...interface{})
+	Warning(args ...interface{})
+	Warningln(args ...interface{})
+	Warningf(format string, args ...interface{})
+	Error(args ...interface{})
+	Errorln(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatal(args ...interface{})
+	Fatalln(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	V(l int) bool
+}
+
+// GRPCLoggerV2 returns a child of the standard logger (see With) as a gRPC
+// logger, see Logger.GRPCLoggerV2.
+func GRPCLoggerV2() GRPCLogger {
+	return std.With().GRPCLoggerV2()
+}
+
+// GRPCLoggerV2 returns l as a gRPC logger, routing the gRPC internal logs
+// through it with their severity:
+//
+//	grpclog.SetLoggerV2(l.GRPCLoggerV2())
+//
+// V follows the level of l, see Logger.V: gRPC verbose logs (V(2)) are only
+// printed at LevelTrace. Fatal messages honor SetFatalPolicy.
+func (l *Logger) GRPCLoggerV2() GRPCLogger {
+	return l
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func TestGRPCLoggerV2(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(g GRPCLogger)
+		want string
+	}{
+		{"Info", func(g GRPCLogger) { g.Info("Ciao", 7) }, lp[0] + "Ciao7"},
+		{"Infoln", func(g GRPCLogger) { g.Infoln("Ciao", 7) }, lp[0] + "Ciao 7"},
+		{"Infof", func(g GRPCLogger) { g.Infof("Ciao %d", 7) }, lp[0] + "Ciao 7"},
+		{"Warning", func(g GRPCLogger) { g.Warning("Ciao", 7) }, lp[1] + "Ciao7"},
+		{"Warningln", func(g GRPCLogger) { g.Warningln("Ciao", 7) }, lp[1] + "Ciao 7"},
+		{"Warningf", func(g GRPCLogger) { g.Warningf("Ciao %d", 7) }, lp[1] + "Ciao 7"},
+		{"Error", func(g GRPCLogger) { g.Error("Ciao", 7) }, lp[2] + "Ciao7"},
+		{"Errorln", func(g GRPCLogger) { g.Errorln("Ciao", 7) }, lp[2] + "Ciao 7"},
+		{"Errorf", func(g GRPCLogger) { g.Errorf("Ciao %d", 7) }, lp[2] + "Ciao 7"},
+		{"Fatal", func(g GRPCLogger) { g.Fatal("Ciao", 7) }, lp[2] + "Ciao7"},
+		{"Fatalln", func(g GRPCLogger) { g.Fatalln("Ciao", 7) }, lp[2] + "Ciao 7"},
+		{"Fatalf", func(g GRPCLogger) { g.Fatalf("Ciao %d", 7) }, lp[2] + "Ciao 7"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+			l.SetFatalPolicy(func(string) bool { return false })
+			tc.f(l.GRPCLoggerV2())
+
+			pattern := ts + "grpc_test.go:[0-9]+: " + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestGRPCLoggerV2Level(t *testing.T) {
+	tt := []struct {
+		level Severity
+		want  [3]bool // V(0) to V(2)
+		info  bool
+	}{
+		{LevelTrace, [3]bool{true, true, true}, true},
+		{LevelDebug, [3]bool{true, true, false}, true},
+		{LevelInfo, [3]bool{true, false, false}, true},
+		{LevelWarning, [3]bool{false, false, false}, false},
+	}
+
+	for _, tc := range tt {
+		w := new(bytes.Buffer)
+		l := New(LevelInfo, WithWriter(w))
+		g := l.GRPCLoggerV2()
+		l.SetLevel(tc.level) // g follows the level set afterwards
+		for n, want := range tc.want {
+			if got := g.V(n); got != want {
+				t.Errorf("level %v: V(%d) want %v, got %v", tc.level, n, want, got)
+			}
+		}
+		g.Info("Ciao")
+		if got := w.Len() != 0; got != tc.info {
+			t.Errorf("level %v: Info printed want %v, got %v", tc.level, tc.info, got)
+		}
+	}
+}
+
+func TestGRPCLoggerV2PackageLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	GRPCLoggerV2().Warning("Ciao")
+
+	pattern := ts + regexp.QuoteMeta(lp[1]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestGRPCLoggerV2PackageLevelCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	defer SetWriter(os.Stdout)
+	Verbose(true)
+	defer Verbose(false)
+
+	g := GRPCLoggerV2()
+	_, _, line, _ := runtime.Caller(0)
+	g.Warning("Ciao")
+	g.Errorf("fmt: %d", 7)
+
+	loc := func(n int) string { return regexp.QuoteMeta("grpc_test.go:" + strconv.Itoa(n) + ": ") }
+	pattern := ts + loc(line+1) + regexp.QuoteMeta(lp[1]+"Ciao") + "\n" + ts[1:] + loc(line+2) + regexp.QuoteMeta(lp[2]+"fmt: 7") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
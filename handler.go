@@ -0,0 +1,51 @@
+package log
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// maxLevelBody is the size limit of the requests to the level handler.
+const maxLevelBody = 1 << 10
+
+// LevelHandler returns an HTTP handler for the standard logger level,
+// see Logger.LevelHandler.
+func LevelHandler() http.Handler {
+	return std.LevelHandler()
+}
+
+// LevelHandler returns an HTTP handler to tune the level at runtime:
+// GET responds with the current level, e.g. "INFO", while PUT and POST set
+// it from a form body like "level=warn" (see ParseLevel) and respond with
+// the new level. An invalid level gets 400 Bad Request.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxLevelBody))
+			if err != nil {
+				http.Error(w, "log: reading the request: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			form, err := url.ParseQuery(string(body))
+			if err != nil {
+				http.Error(w, "log: bad form: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, err := ParseLevel(form.Get("level"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			l.SetLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+			http.Error(w, "log: method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, l.Level().String()+"\n") // #nosec
+	})
+}
@@ -0,0 +1,61 @@
+package log
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	tt := []struct {
+		name   string
+		method string
+		body   string
+		code   int
+		want   string
+		level  Severity
+	}{
+		{"get", http.MethodGet, "", http.StatusOK, "INFO\n", LevelInfo},
+		{"put", http.MethodPut, "level=warn", http.StatusOK, "WARN\n", LevelWarning},
+		{"post", http.MethodPost, "level=DEBUG", http.StatusOK, "DEBUG\n", LevelDebug},
+		{"off", http.MethodPost, "level=off", http.StatusOK, "OFF\n", LevelOff},
+		{"invalid", http.MethodPut, "level=loud", http.StatusBadRequest, "log: unknown level \"loud\"\n", LevelInfo},
+		{"missing", http.MethodPut, "lvl=warn", http.StatusBadRequest, "log: unknown level \"\"\n", LevelInfo},
+		{"bad form", http.MethodPut, "level=%zz", http.StatusBadRequest, "", LevelInfo},
+		{"method", http.MethodDelete, "", http.StatusMethodNotAllowed, "log: method not allowed\n", LevelInfo},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New(LevelInfo)
+			srv := httptest.NewServer(l.LevelHandler())
+			defer srv.Close()
+
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tc.code {
+				t.Errorf("status: want %d, got %d", tc.code, resp.StatusCode)
+			}
+			if tc.want != "" && string(body) != tc.want {
+				t.Errorf("body: want %q, got %q", tc.want, string(body))
+			}
+			if got := l.Level(); got != tc.level {
+				t.Errorf("level: want %v, got %v", tc.level, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Heartbeat logs an "alive" message every d, see Logger.Heartbeat.
+func Heartbeat(level Severity, d time.Duration, fields func() []interface{}) (stop func()) {
+	return std.Heartbeat(level, d, fields)
+}
+
+// Heartbeat logs an "alive" message of the given level every d until the
+// returned function or Close is called. If fields is not nil, it is called on
+// every tick and the key/value pairs it returns are appended to the message,
+// e.g. "alive uptime=1h0m0s goroutines=12".
+// A d less than or equal to zero starts no heartbeat, e.g. to disable it
+// from the configuration.
+func (l *Logger) Heartbeat(level Severity, d time.Duration, fields func() []interface{}) (stop func()) {
+	if d <= 0 {
+		return func() {}
+	}
+	hb := &heartbeat{done: make(chan struct{})}
+
+	l.mu.Lock()
+	if l.beats == nil {
+		l.beats = map[*heartbeat]struct{}{}
+	}
+	l.beats[hb] = struct{}{}
+	l.mu.Unlock()
+
+	hb.wg.Add(1)
+	go func() {
+		defer hb.wg.Done()
+
+		t := time.NewTicker(d)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				if !l.Enabled(level) {
+					continue
+				}
+				msg := "alive"
+				if fields != nil {
+					msg += formatFields(fields())
+				}
+				l.output(level, msg)
+			case <-hb.done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		l.mu.Lock()
+		delete(l.beats, hb)
+		l.mu.Unlock()
+		hb.stop()
+	}
+}
+
+// stopHeartbeats stops all the running heartbeats of the logger.
+func (l *Logger) stopHeartbeats() {
+	l.mu.Lock()
+	beats := l.beats
+	l.beats = nil
+	l.mu.Unlock()
+
+	for hb := range beats {
+		hb.stop()
+	}
+}
+
+// heartbeat is a running Heartbeat.
+type heartbeat struct {
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// stop stops the heartbeat and waits for its goroutine to return.
+func (hb *heartbeat) stop() {
+	hb.once.Do(func() { close(hb.done) })
+	hb.wg.Wait()
+}
+
+// formatFields renders alternating keys and values as " key=value" pairs.
+// A dangling key gets the value "<missing>".
+func formatFields(kv []interface{}) string {
+	var b strings.Builder
+	for i := 0; i < len(kv); i += 2 {
+		var v interface{} = "<missing>"
+		if i+1 < len(kv) {
+			v = kv[i+1]
+		}
+		fmt.Fprintf(&b, " %v=%v", kv[i], v)
+	}
+	return b.String()
+}
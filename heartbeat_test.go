@@ -0,0 +1,126 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a strings.Builder safe for concurrent use.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+// waitFor polls f until it returns true, failing the test after a second.
+func waitFor(t *testing.T, f func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !f() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	w := new(syncBuffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+
+	var ticks int
+	stop := l.Heartbeat(LevelWarning, time.Millisecond, func() []interface{} {
+		ticks++
+		return []interface{}{"tick", ticks, "up"}
+	})
+	waitFor(t, func() bool { return strings.Count(w.String(), "\n") >= 2 })
+	stop()
+	stop()
+
+	n := strings.Count(w.String(), "\n")
+	time.Sleep(5 * time.Millisecond)
+	if got := strings.Count(w.String(), "\n"); got != n {
+		t.Fatalf("heartbeat still running after stop: %d lines, then %d", n, got)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	for i, line := range lines[:2] {
+		pattern := ts + lp[1] + "alive tick=" + string(rune('1'+i)) + " up=<missing>$"
+		if !regexp.MustCompile(pattern).MatchString(line) {
+			t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+		}
+	}
+}
+
+func TestHeartbeatClose(t *testing.T) {
+	w := new(syncBuffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+
+	l.Heartbeat(LevelInfo, time.Millisecond, nil)
+	l.Heartbeat(LevelInfo, time.Millisecond, nil)
+	waitFor(t, func() bool { return w.String() != "" })
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	n := strings.Count(w.String(), "\n")
+	time.Sleep(5 * time.Millisecond)
+	if got := strings.Count(w.String(), "\n"); got != n {
+		t.Fatalf("heartbeat still running after Close: %d lines, then %d", n, got)
+	}
+	if !regexp.MustCompile(ts + lp[0] + "alive$").MatchString(strings.Split(w.String(), "\n")[0]) {
+		t.Errorf("unexpected heartbeat line %q", w.String())
+	}
+}
+
+func TestHeartbeatLevel(t *testing.T) {
+	w := new(syncBuffer)
+	l := New(LevelWarning)
+	l.SetWriter(w)
+
+	called := make(chan struct{}, 1)
+	stop := l.Heartbeat(LevelInfo, time.Millisecond, func() []interface{} {
+		called <- struct{}{}
+		return nil
+	})
+	time.Sleep(5 * time.Millisecond)
+	stop()
+
+	if w.String() != "" {
+		t.Errorf("heartbeat logged below level: %q", w.String())
+	}
+	if len(called) != 0 {
+		t.Error("fields evaluated below level")
+	}
+}
+
+func TestHeartbeatNonPositive(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		w := new(syncBuffer)
+		l := New(LevelInfo, WithWriter(w))
+		stop := l.Heartbeat(LevelInfo, d, nil)
+		time.Sleep(10 * time.Millisecond)
+		stop()
+		l.Close() // #nosec
+
+		if got := w.String(); got != "" {
+			t.Errorf("d %v: want no output, got %q", d, got)
+		}
+	}
+}
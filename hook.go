@@ -0,0 +1,88 @@
+package log
+
+// Hook is notified of the messages logged at its levels, e.g. to count the
+// errors in a metric.
+type Hook interface {
+	// Fire is called with the level and the text of the message, fields
+	// included.
+	Fire(level Severity, msg string)
+	// Levels returns the levels the hook fires for.
+	Levels() []Severity
+}
+
+// AddHook adds h to the hooks of the standard logger, see Logger.AddHook.
+func AddHook(h Hook) {
+	std.AddHook(h)
+}
+
+// AddHook makes the logger fire h for every message printed at one of the
+// levels of h. Hooks run synchronously, in the order they were added, once
+// the message is written and the output lock released, so that a hook may
+// log through the logger itself; a panicking hook is recovered and does not
+// affect the other hooks.
+// Loggers derived afterwards, e.g. by With, inherit the hooks.
+func (l *Logger) AddHook(h Hook) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], h)
+}
+
+// RemoveHook removes h from the hooks of the standard logger, see
+// Logger.RemoveHook.
+func RemoveHook(h Hook) {
+	std.RemoveHook(h)
+}
+
+// RemoveHook removes h from the hooks of the logger, comparing them with ==,
+// so h should be comparable, e.g. a pointer.
+// Loggers derived before the call keep firing h.
+func (l *Logger) RemoveHook(h Hook) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	hooks := make([]Hook, 0, len(l.hooks))
+	for _, x := range l.hooks {
+		if x != h {
+			hooks = append(hooks, x)
+		}
+	}
+	l.hooks = hooks
+}
+
+// firing is a message to notify the hooks of, see unlock.
+type firing struct {
+	hooks []Hook
+	level Severity
+	msg   string
+}
+
+// unlock releases l.omu, then fires the hooks for the messages emitted in
+// the meantime.
+func (l *Logger) unlock() {
+	fs := l.firings
+	l.firings = nil
+	l.omu.Unlock()
+
+	for _, f := range fs {
+		fireHooks(f.hooks, f.level, f.msg)
+	}
+}
+
+// fireHooks calls the hooks registered for level.
+func fireHooks(hooks []Hook, level Severity, msg string) {
+	for _, h := range hooks {
+		for _, hl := range h.Levels() {
+			if hl == level {
+				fire(h, level, msg)
+				break
+			}
+		}
+	}
+}
+
+// fire calls h, recovering a panic.
+func fire(h Hook, level Severity, msg string) {
+	defer func() { recover() }() // #nosec
+	h.Fire(level, msg)
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// recordHook records the messages it fires for.
+type recordHook struct {
+	levels []Severity
+	fired  []string
+	panics bool
+}
+
+func (h *recordHook) Fire(level Severity, msg string) {
+	h.fired = append(h.fired, level.String()+" "+msg)
+	if h.panics {
+		panic("hook")
+	}
+}
+
+func (h *recordHook) Levels() []Severity {
+	return h.levels
+}
+
+func TestAddHook(t *testing.T) {
+	tt := []struct {
+		name   string
+		levels []Severity
+		panics bool
+		f      func(l *Logger)
+		want   []string
+	}{
+		{"error only", []Severity{LevelError}, false, func(l *Logger) { l.Info("a"); l.Error("b"); l.Warning("c") }, []string{"ERROR b"}},
+		{"several levels", []Severity{LevelInfo, LevelWarning}, false, func(l *Logger) { l.Info("a"); l.Error("b"); l.Warningln("c") }, []string{"INFO a", "WARN c"}},
+		{"filtered level", []Severity{LevelDebug}, false, func(l *Logger) { l.Debug("a") }, nil},
+		{"fields", []Severity{LevelInfo}, false, func(l *Logger) { l.With("k", 1).Info("a") }, []string{"INFO a k=1"}},
+		{"no levels", nil, false, func(l *Logger) { l.Error("a") }, nil},
+		{"panic", []Severity{LevelInfo}, true, func(l *Logger) { l.Info("a"); l.Info("b") }, []string{"INFO a", "INFO b"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			h := &recordHook{levels: tc.levels, panics: tc.panics}
+			l.AddHook(h)
+			tc.f(l)
+
+			if !reflect.DeepEqual(h.fired, tc.want) {
+				t.Errorf("mismatch! Want %q, got %q", tc.want, h.fired)
+			}
+			if lines := bytes.Count(w.Bytes(), []byte("\n")); lines == 0 && tc.want != nil {
+				t.Errorf("nothing written, got %q", w.String())
+			}
+		})
+	}
+}
+
+func TestAddHookOrder(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	var order []string
+	l.AddHook(funcHook(func(Severity, string) { order = append(order, "first") }))
+	l.AddHook(funcHook(func(Severity, string) { panic("second") }))
+	l.AddHook(funcHook(func(Severity, string) { order = append(order, "third") }))
+	l.Error("Ciao")
+
+	if want := []string{"first", "third"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("mismatch! Want %q, got %q", want, order)
+	}
+	pattern := ts + regexp.QuoteMeta(lp[2]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestHookLogging(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetTimestamp(false)
+	c := l.With("a", 1)
+	l.AddHook(funcHook(func(level Severity, msg string) {
+		if level == LevelError {
+			l.Info("hook: " + msg)
+			c.Info("child")
+		}
+	}))
+	l.Table(LevelError, map[string]string{"k": "v"})
+
+	want := lp[2] + "k  v\n" + lp[0] + "hook: k  v\n" + lp[0] + "child a=1\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+}
+
+func TestRemoveHook(t *testing.T) {
+	l := New(LevelInfo, WithWriter(new(bytes.Buffer)))
+	a := &recordHook{levels: []Severity{LevelInfo}}
+	b := &recordHook{levels: []Severity{LevelInfo}}
+	l.AddHook(a)
+	l.AddHook(b)
+	c := l.With("k", 1)
+	l.Info("a")
+	l.RemoveHook(a)
+	l.Info("b")
+	c.Info("c")
+
+	if want := []string{"INFO a", "INFO c k=1"}; !reflect.DeepEqual(a.fired, want) {
+		t.Errorf("removed hook: want %q, got %q", want, a.fired)
+	}
+	if want := []string{"INFO a", "INFO b", "INFO c k=1"}; !reflect.DeepEqual(b.fired, want) {
+		t.Errorf("kept hook: want %q, got %q", want, b.fired)
+	}
+}
+
+// funcHook is a hook firing the function for every level.
+type funcHook func(level Severity, msg string)
+
+func (f funcHook) Fire(level Severity, msg string) { f(level, msg) }
+
+func (funcHook) Levels() []Severity { return Levels() }
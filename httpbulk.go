@@ -0,0 +1,240 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HTTPBulkWriter is a writer buffering log lines and POSTing them in batches
+// to an HTTP ingest endpoint accepting newline delimited payloads (NDJSON).
+// A batch is sent in the background when its size reaches the batch size and
+// when the flush interval elapses, so that a slow endpoint does not hold up
+// the logging calls, and synchronously on Flush and Close.
+type HTTPBulkWriter struct {
+	url      string
+	size     int
+	client   *http.Client
+	retries  int
+	backoff  time.Duration
+	onError  func(error)
+	fallback io.Writer
+	jitter   atomic.Uint64 // float64 bits of the interval jitter fraction
+
+	mu   sync.Mutex // guards buf and full
+	buf  bytes.Buffer
+	full [][]byte      // batches waiting to be sent, oldest first
+	smu  sync.Mutex    // serializes sends to keep batches ordered
+	kick chan struct{} // wakes up the background sender
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// defaultBulkTimeout is the timeout of the default HTTPBulkWriter client.
+const defaultBulkTimeout = 10 * time.Second
+
+// NewHTTPBulkWriter returns a writer POSTing batches of at least batchSize
+// bytes to url. If interval is greater than zero, pending lines are also sent
+// every interval. By default a request times out after 10s and a failed batch
+// is retried 3 times with an exponential backoff starting at 100ms.
+func NewHTTPBulkWriter(url string, batchSize int, interval time.Duration) *HTTPBulkWriter {
+	w := &HTTPBulkWriter{
+		url:     url,
+		size:    batchSize,
+		client:  &http.Client{Timeout: defaultBulkTimeout},
+		retries: 3,
+		backoff: 100 * time.Millisecond,
+		kick:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.loop(interval)
+	return w
+}
+
+// SetClient sets the HTTP client used to send batches.
+// It must be called before the writer is used.
+func (w *HTTPBulkWriter) SetClient(c *http.Client) {
+	w.client = c
+}
+
+// SetRetries sets how many times a failed batch is retried and the initial
+// delay between attempts, doubled after each attempt.
+// It must be called before the writer is used.
+func (w *HTTPBulkWriter) SetRetries(n int, backoff time.Duration) {
+	w.retries, w.backoff = n, backoff
+}
+
+// SetErrorHandler sets a function called with the error of every batch
+// which could not be delivered, including those sent in the background.
+// It must be called before the writer is used.
+func (w *HTTPBulkWriter) SetErrorHandler(fn func(error)) {
+	w.onError = fn
+}
+
+// SetFallback sets a writer, usually a file, receiving every batch which
+// could not be delivered.
+// It must be called before the writer is used.
+func (w *HTTPBulkWriter) SetFallback(fw io.Writer) {
+	w.fallback = fw
+}
+
+// SetFlushJitter randomizes the flush interval by up to ±fraction of its
+// duration (e.g. 0.1 for ±10%), so that a fleet of processes started together
+// does not flush in lockstep. fraction is clamped to [0, 1]; 0 disables it
+// (default).
+func (w *HTTPBulkWriter) SetFlushJitter(fraction float64) {
+	fraction = math.Max(0, math.Min(1, fraction))
+	w.jitter.Store(math.Float64bits(fraction))
+}
+
+// Write buffers p, handing the pending batch to the background sender if it
+// reached the batch size. It never waits for the endpoint.
+func (w *HTTPBulkWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.buf.Write(p)
+	full := w.buf.Len() >= w.size
+	if full {
+		w.full = append(w.full, append([]byte(nil), w.buf.Bytes()...))
+		w.buf.Reset()
+	}
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.kick <- struct{}{}:
+		default: // already woken up
+		}
+	}
+	return len(p), nil
+}
+
+// ConcurrentSafe implements ConcurrentWriter.
+func (w *HTTPBulkWriter) ConcurrentSafe() {}
+
+// Flush sends the pending batches, if any.
+// Errors from every batch are joined together.
+func (w *HTTPBulkWriter) Flush() error {
+	return w.sendBatches(true)
+}
+
+// sendBatches sends the batches which reached the batch size and, if all,
+// the pending lines. Errors from every batch are joined together.
+func (w *HTTPBulkWriter) sendBatches(all bool) error {
+	w.smu.Lock()
+	defer w.smu.Unlock()
+
+	w.mu.Lock()
+	batches := w.full
+	w.full = nil
+	if all && w.buf.Len() > 0 {
+		batches = append(batches, append([]byte(nil), w.buf.Bytes()...))
+		w.buf.Reset()
+	}
+	w.mu.Unlock()
+
+	var errs []error
+	for _, batch := range batches {
+		if err := w.send(batch); err != nil {
+			if w.onError != nil {
+				w.onError(err)
+			}
+			if w.fallback != nil {
+				w.fallback.Write(batch) // #nosec
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the background sender and sends the remaining batches.
+func (w *HTTPBulkWriter) Close() error {
+	w.once.Do(func() { close(w.done) })
+	w.wg.Wait()
+	return w.Flush()
+}
+
+// loop sends the batches reaching the batch size and, if d is greater than
+// zero, flushes the pending lines every d, give or take the jitter, until the
+// writer is closed.
+func (w *HTTPBulkWriter) loop(d time.Duration) {
+	defer w.wg.Done()
+
+	var tick <-chan time.Time
+	var t *time.Timer
+	if d > 0 {
+		t = time.NewTimer(w.next(d))
+		defer t.Stop()
+		tick = t.C
+	}
+	for {
+		select {
+		case <-w.kick:
+			w.sendBatches(false) // #nosec
+		case <-tick:
+			w.Flush() // #nosec
+			t.Reset(w.next(d))
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// next returns the delay until the next periodic flush.
+func (w *HTTPBulkWriter) next(d time.Duration) time.Duration {
+	return jittered(d, math.Float64frombits(w.jitter.Load()), rand.Float64()) // #nosec
+}
+
+// jittered returns d shifted by fraction of itself, scaled by r in [0, 1)
+// mapped onto [-1, 1).
+func jittered(d time.Duration, fraction, r float64) time.Duration {
+	return d + time.Duration(float64(d)*fraction*(2*r-1))
+}
+
+// send POSTs batch, retrying on network errors and on 429 or 5xx statuses.
+func (w *HTTPBulkWriter) send(batch []byte) error {
+	backoff := w.backoff
+	var err error
+	for i := 0; i <= w.retries; i++ {
+		if i > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = w.post(batch)
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// post makes a single delivery attempt, reporting whether a failure can be
+// retried.
+func (w *HTTPBulkWriter) post(batch []byte) (bool, error) {
+	resp, err := w.client.Post(w.url, "application/x-ndjson", bytes.NewReader(batch))
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, resp.Body) // #nosec
+	resp.Body.Close()              // #nosec
+
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	err = fmt.Errorf("log: bulk POST to %s: %s", w.url, resp.Status)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
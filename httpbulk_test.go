@@ -0,0 +1,221 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bulkServer records the bodies POSTed to it, failing the first fails requests.
+type bulkServer struct {
+	mu     sync.Mutex
+	fails  int
+	status int
+	bodies []string
+}
+
+func (s *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fails > 0 {
+		s.fails--
+		w.WriteHeader(s.status)
+		return
+	}
+	b, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, string(b))
+}
+
+func (s *bulkServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.bodies...)
+}
+
+func TestHTTPBulkWriterBatch(t *testing.T) {
+	tt := []struct {
+		name  string
+		size  int
+		lines []string
+		want  []string
+	}{
+		{"single batch on close", 1024, []string{"a\n", "b\n", "c\n"}, []string{"a\nb\nc\n"}},
+		{"batch by size", 4, []string{"a\n", "b\n", "c\n"}, []string{"a\nb\n", "c\n"}},
+		{"nothing to send", 4, nil, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := new(bulkServer)
+			srv := httptest.NewServer(s)
+			defer srv.Close()
+
+			w := NewHTTPBulkWriter(srv.URL, tc.size, 0)
+			for _, line := range tc.lines {
+				w.Write([]byte(line))
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("unexpected error on Close: %v", err)
+			}
+
+			got := s.received()
+			if len(got) != len(tc.want) {
+				t.Fatalf("want %q, got %q", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("batch %d: want %q, got %q", i, tc.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPBulkWriterInterval(t *testing.T) {
+	s := new(bulkServer)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w := NewHTTPBulkWriter(srv.URL, 1024, time.Millisecond)
+	defer w.Close()
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.Info("Ciao")
+
+	deadline := time.Now().Add(time.Second)
+	for len(s.received()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("batch not sent on interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHTTPBulkWriterRetry(t *testing.T) {
+	tt := []struct {
+		name     string
+		fails    int
+		status   int
+		wantErr  bool
+		wantSent int
+	}{
+		{"recovers after server errors", 2, http.StatusServiceUnavailable, false, 1},
+		{"gives up after retries", 4, http.StatusInternalServerError, true, 0},
+		{"no retry on client error", 1, http.StatusBadRequest, true, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &bulkServer{fails: tc.fails, status: tc.status}
+			srv := httptest.NewServer(s)
+			defer srv.Close()
+
+			var handled error
+			fallback := new(bytes.Buffer)
+			w := NewHTTPBulkWriter(srv.URL, 1024, 0)
+			w.SetRetries(3, time.Millisecond)
+			w.SetErrorHandler(func(err error) { handled = err })
+			w.SetFallback(fallback)
+			w.Write([]byte("Ciao\n"))
+
+			err := w.Close()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error value %v", err)
+			}
+			if handled != err {
+				t.Errorf("error handler: want %v, got %v", err, handled)
+			}
+			if got := len(s.received()); got != tc.wantSent {
+				t.Errorf("want %d batches delivered, got %d", tc.wantSent, got)
+			}
+			if want := map[bool]string{true: "Ciao\n"}[tc.wantErr]; fallback.String() != want {
+				t.Errorf("fallback: want %q, got %q", want, fallback.String())
+			}
+		})
+	}
+}
+
+func TestJittered(t *testing.T) {
+	const d = time.Second
+
+	tt := []struct {
+		name     string
+		fraction float64
+		r        float64
+		want     time.Duration
+	}{
+		{"no jitter", 0, 0, d},
+		{"lowest", 0.1, 0, 900 * time.Millisecond},
+		{"middle", 0.1, 0.5, d},
+		{"highest", 0.1, 0.75, 1050 * time.Millisecond},
+		{"full", 1, 0, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jittered(d, tc.fraction, tc.r); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHTTPBulkWriterJitter(t *testing.T) {
+	s := new(bulkServer)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w := NewHTTPBulkWriter(srv.URL, 1024, time.Millisecond)
+	w.SetFlushJitter(2) // clamped to 1
+	defer w.Close()
+	w.Write([]byte("Ciao\n"))
+
+	deadline := time.Now().Add(time.Second)
+	for len(s.received()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("batch not sent on jittered interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHTTPBulkWriterHungEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	w := NewHTTPBulkWriter(srv.URL, 1, 0)
+	w.SetRetries(0, 0)
+	l := New(LevelInfo, WithWriter(w))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			l.Info("Ciao")
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Write blocked on a hung endpoint")
+	}
+	close(release)
+	w.Close() // #nosec
+}
+
+func TestHTTPBulkWriterClientTimeout(t *testing.T) {
+	w := NewHTTPBulkWriter("http://127.0.0.1", 1024, 0)
+	defer w.Close()
+
+	if w.client.Timeout != defaultBulkTimeout {
+		t.Errorf("want client timeout %v, got %v", defaultBulkTimeout, w.client.Timeout)
+	}
+}
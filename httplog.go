@@ -0,0 +1,104 @@
+package log
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultOmittedHeaders are the request headers left out by Request unless
+// SetOmittedHeaders is called.
+var defaultOmittedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// StatusLevel returns the level of an HTTP response of the given status:
+// LevelError for 5xx, LevelWarning for 4xx and LevelInfo otherwise.
+func StatusLevel(status int) Severity {
+	switch {
+	case status >= 500:
+		return LevelError
+	case status >= 400:
+		return LevelWarning
+	}
+	return LevelInfo
+}
+
+// Request logs r on the standard output, see Logger.Request.
+func Request(r *http.Request) {
+	std.Request(r)
+}
+
+// Response logs a response on the standard output, see Logger.Response.
+func Response(status, size int, dur time.Duration) {
+	std.Response(status, size, dur)
+}
+
+// SetOmittedHeaders sets the request headers left out by the standard
+// logger, see Logger.SetOmittedHeaders.
+func SetOmittedHeaders(names ...string) {
+	std.SetOmittedHeaders(names...)
+}
+
+// Request logs an Info level "http request" message with the method, path
+// and remote fields, followed by the request headers as "header.Name"
+// fields sorted by name, e.g. for an access log middleware. The query string
+// is left out, as are the sensitive headers (see SetOmittedHeaders).
+// Log message is emitted only if the current logging level is equal or less than LevelInfo.
+func (l *Logger) Request(r *http.Request) {
+	if l.Level() > LevelInfo {
+		return
+	}
+
+	l.mu.Lock()
+	omitted := l.omitted
+	l.mu.Unlock()
+	if omitted == nil {
+		omitted = defaultOmittedHeaders
+	}
+
+	names := make([]string, 0, len(r.Header))
+	for name := range r.Header {
+		if !omitted[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	kv := make([]interface{}, 0, 6+2*len(names))
+	kv = append(kv, "method", r.Method, "path", r.URL.Path, "remote", r.RemoteAddr)
+	for _, name := range names {
+		kv = append(kv, "header."+http.CanonicalHeaderKey(name), strings.Join(r.Header[name], ", "))
+	}
+	l.outputFields(LevelInfo, "http request", kv)
+}
+
+// Response logs an "http response" message with the status, bytes and
+// latency fields, at the level of the status (see StatusLevel).
+// Log message is emitted only if the current logging level is equal or less than that level.
+func (l *Logger) Response(status, size int, dur time.Duration) {
+	level := StatusLevel(status)
+	if l.Level() > level {
+		return
+	}
+	l.outputFields(level, "http response", []interface{}{"status", status, "bytes", size, "latency", dur})
+}
+
+// SetOmittedHeaders sets the request headers left out by Request, replacing
+// the default ones: Authorization, Proxy-Authorization, Cookie and
+// Set-Cookie. Without names, every header is logged.
+func (l *Logger) SetOmittedHeaders(names ...string) {
+	omitted := make(map[string]bool, len(names))
+	for _, name := range names {
+		omitted[http.CanonicalHeaderKey(name)] = true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.omitted = omitted
+}
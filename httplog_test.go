@@ -0,0 +1,92 @@
+package log
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestStatusLevel(t *testing.T) {
+	tt := []struct {
+		status int
+		want   Severity
+	}{
+		{200, LevelInfo},
+		{304, LevelInfo},
+		{404, LevelWarning},
+		{500, LevelError},
+		{503, LevelError},
+	}
+
+	for _, tc := range tt {
+		if got := StatusLevel(tc.status); got != tc.want {
+			t.Errorf("%d: want %v, got %v", tc.status, tc.want, got)
+		}
+	}
+}
+
+func TestRequest(t *testing.T) {
+	tt := []struct {
+		name    string
+		omitted []string
+		want    string
+	}{
+		{"default omitted", nil, lp[0] + "http request method=GET path=/users remote=192.0.2.1:1234 header.Accept=a, b header.X-Api-Key=k"},
+		{"custom omitted", []string{"x-api-key"}, lp[0] + "http request method=GET path=/users remote=192.0.2.1:1234 header.Accept=a, b header.Authorization=Bearer t header.Cookie=c"},
+		{"none omitted", []string{}, lp[0] + "http request method=GET path=/users remote=192.0.2.1:1234 header.Accept=a, b header.Authorization=Bearer t header.Cookie=c header.X-Api-Key=k"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+			if tc.omitted != nil {
+				l.SetOmittedHeaders(tc.omitted...)
+			}
+			r := httptest.NewRequest("GET", "/users?token=secret", nil)
+			r.Header.Add("Accept", "a")
+			r.Header.Add("Accept", "b")
+			r.Header.Set("Authorization", "Bearer t")
+			r.Header.Set("Cookie", "c")
+			r.Header.Set("X-Api-Key", "k")
+			l.Request(r)
+
+			pattern := ts + "httplog_test.go:[0-9]+: " + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	tt := []struct {
+		name   string
+		level  Severity
+		status int
+		want   string
+	}{
+		{"ok", LevelInfo, 200, lp[0] + "http response status=200 bytes=512 latency=1.5ms"},
+		{"not found", LevelInfo, 404, lp[1] + "http response status=404 bytes=512 latency=1.5ms"},
+		{"server error", LevelInfo, 500, lp[2] + "http response status=500 bytes=512 latency=1.5ms"},
+		{"disabled", LevelWarning, 200, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.level, WithWriter(w))
+			l.Response(tc.status, 512, 1500*time.Microsecond)
+
+			pattern := "^$"
+			if tc.want != "" {
+				pattern = ts + regexp.QuoteMeta(tc.want) + "\n$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// header matches what the standard library logger may print before our level
+// label: date, time and caller.
+var header = regexp.MustCompile(`^(?:[0-9]{4}/[0-9]{2}/[0-9]{2} )?(?:[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)? )?([^ ]+:[0-9]+: )?`)
+
+// IngestWriter returns a writer re-logging the lines written by another
+// logger of this package, e.g. the output of a child process.
+// See Logger.IngestWriter.
+func IngestWriter(def Severity) *LineWriter {
+	return std.IngestWriter(def)
+}
+
+// IngestWriter returns a writer re-logging the lines written by another
+// logger of this package, e.g. the output of a child process.
+// The timestamp and default level label of each line are stripped and the
+// line is logged at the level of the label, keeping any caller information.
+// Lines without a recognized label are logged as they are at level def.
+func (l *Logger) IngestWriter(def Severity) *LineWriter {
+	return &LineWriter{f: func(line string) {
+		level, msg := parseLine(line, def)
+		if !l.Enabled(level) {
+			return
+		}
+		l.outputAt(writerCaller(), level, msg)
+	}}
+}
+
+// WriterFor returns a writer logging every line written to it at the given
+// level on the standard logger, see Logger.WriterFor.
+func WriterFor(level Severity) *LineWriter {
+	return std.WriterFor(level)
+}
+
+// WriterFor returns a writer logging every line written to it at the given
+// level, without its newline, e.g. for libraries expecting an io.Writer or a
+// standard library logger:
+//
+//	srv := &http.Server{ErrorLog: stdlog.New(l.WriterFor(log.LevelError), "", 0)}
+//
+// A partial line is kept until its newline is written, or Flush is called.
+// The caller reported (see Verbose) is the code writing to the writer, or
+// calling the standard library logger.
+func (l *Logger) WriterFor(level Severity) *LineWriter {
+	return &LineWriter{f: func(line string) {
+		if !l.Enabled(level) {
+			return
+		}
+		l.outputAt(writerCaller(), level, line)
+	}}
+}
+
+// outputAt is like output, reporting pc as the caller.
+func (l *Logger) outputAt(pc uintptr, level Severity, s string) {
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(1, pc, level, "", s, nil)
+}
+
+// ingestFile is the file of the writer adapters, see writerCaller.
+var _, ingestFile, _, _ = runtime.Caller(0)
+
+// writerCaller returns the pc of the first caller outside of the writer
+// adapters and of the standard library log, fmt and io packages writing to
+// them, zero if there is none.
+func writerCaller() uintptr {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	for _, pc := range pcs[:n] {
+		f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if f.File == ingestFile {
+			continue
+		}
+		if pkg, _, _ := strings.Cut(f.Function, "."); pkg != "log" && pkg != "fmt" && pkg != "io" {
+			return pc
+		}
+	}
+	return 0
+}
+
+// StdLogger returns a standard library logger writing through the standard
+// logger at the given level, see Logger.StdLogger.
+func StdLogger(level Severity) *log.Logger {
+	return std.StdLogger(level)
+}
+
+// StdLogger returns a standard library logger writing through l at the given
+// level (see WriterFor), for dependencies insisting on a *log.Logger.
+// It has no flags, l adding its own timestamp; setting some would print
+// them in the middle of the line.
+// Its level is fixed: every line is logged at level, whatever its content,
+// bypassing the per-level routing of the messages; see IngestWriter to log
+// the lines at the level of their label instead.
+// The caller reported (see Verbose) is the code calling the returned logger.
+func (l *Logger) StdLogger(level Severity) *log.Logger {
+	return log.New(l.WriterFor(level), "", 0)
+}
+
+// DefaultStdLogger returns a standard library logger writing through the
+// standard logger at its default level, see Logger.DefaultStdLogger.
+func DefaultStdLogger() *log.Logger {
+	return std.DefaultStdLogger()
+}
+
+// DefaultStdLogger is like StdLogger at the default level of l (see
+// SetDefaultLevel), the one of Print, with the prefix and flags of l.
+// The level is the default one when DefaultStdLogger is called and stays
+// fixed afterwards.
+func (l *Logger) DefaultStdLogger() *log.Logger {
+	return l.StdLogger(l.DefaultLevel())
+}
+
+// parseLine returns the level and the message of a line printed by a logger
+// of this package, or def and the whole line if it does not look like one.
+func parseLine(line string, def Severity) (Severity, string) {
+	m := header.FindStringSubmatchIndex(line)
+	var src string
+	if m[2] >= 0 {
+		src = line[m[2]:m[3]]
+	}
+	rest := line[m[1]:]
+	for _, level := range Levels() {
+		if p := prefix[level]; strings.HasPrefix(rest, p) {
+			return level, src + rest[len(p):]
+		}
+	}
+	return def, line
+}
+
+// LineWriter is a writer calling a function for each line written to it.
+// Partial lines are buffered until their newline is written, or Flush is called.
+type LineWriter struct {
+	f   func(line string)
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write buffers p, passing on every complete line without its newline.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.f(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// ConcurrentSafe implements ConcurrentWriter.
+func (w *LineWriter) ConcurrentSafe() {}
+
+// Flush passes on the pending partial line, if any.
+func (w *LineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.f(string(w.buf))
+		w.buf = w.buf[:0]
+	}
+	return nil
+}
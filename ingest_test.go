@@ -0,0 +1,236 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIngestWriter(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		min   Severity
+		want  []string
+	}{
+		{"child info", "2022/08/13 10:00:00.000000 INFO> Ciao\n", LevelInfo, []string{lp[0] + "Ciao"}},
+		{"child error", "2022/08/13 10:00:00.000000 ERROR> Ciao\n", LevelInfo, []string{lp[2] + "Ciao"}},
+		{"child no timestamp", "WARN> Ciao\n", LevelInfo, []string{lp[1] + "Ciao"}},
+		{"child debug", "DEBUG> Ciao\n", LevelDebug, []string{dp + "Ciao"}},
+		{"child verbose", "2022/08/13 10:00:00.000000 main.go:12: WARN> Ciao\n", LevelInfo, []string{lp[1] + "main.go:12: Ciao"}},
+		{"foreign line", "panic: Ciao\n", LevelInfo, []string{lp[1] + "panic: Ciao"}},
+		{"label not at start", "said INFO> Ciao\n", LevelInfo, []string{lp[1] + "said INFO> Ciao"}},
+		{"multiple lines", "INFO> Ciao\nERROR> ciao\n", LevelInfo, []string{lp[0] + "Ciao", lp[2] + "ciao"}},
+		{"below level", "INFO> Ciao\nERROR> ciao\n", LevelWarning, []string{lp[2] + "ciao"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.min)
+			l.SetWriter(w)
+			iw := l.IngestWriter(LevelWarning)
+			if _, err := iw.Write([]byte(tc.input)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+			if len(lines) != len(tc.want) {
+				t.Fatalf("want %d lines, got %q", len(tc.want), lines)
+			}
+			for i, line := range lines {
+				pattern := ts + regexp.QuoteMeta(tc.want[i]) + "$"
+				if !regexp.MustCompile(pattern).MatchString(line) {
+					t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+				}
+			}
+		})
+	}
+}
+
+func TestLineWriterPartial(t *testing.T) {
+	var got []string
+	w := &LineWriter{f: func(line string) { got = append(got, line) }}
+
+	w.Write([]byte("Ci"))
+	if len(got) != 0 {
+		t.Fatalf("partial line passed on: %q", got)
+	}
+	w.Write([]byte("ao\nciao"))
+	if len(got) != 1 || got[0] != "Ciao" {
+		t.Fatalf("want [Ciao], got %q", got)
+	}
+	w.Flush()
+	if len(got) != 2 || got[1] != "ciao" {
+		t.Fatalf("want [Ciao ciao] after Flush, got %q", got)
+	}
+	w.Flush()
+	if len(got) != 2 {
+		t.Errorf("empty Flush passed on a line: %q", got)
+	}
+}
+
+func TestWriterFor(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		in    []string
+		want  []string
+	}{
+		{"single", LevelError, []string{"Ciao\n"}, []string{lp[2] + "Ciao"}},
+		{"multi-line", LevelWarning, []string{"one\ntwo\nthree\n"}, []string{lp[1] + "one", lp[1] + "two", lp[1] + "three"}},
+		{"partial", LevelInfo, []string{"o", "ne\ntw", "o\n"}, []string{lp[0] + "one", lp[0] + "two"}},
+		{"disabled", LevelDebug, []string{"Ciao\n"}, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			lw := l.WriterFor(tc.level)
+			for _, in := range tc.in {
+				if n, err := lw.Write([]byte(in)); n != len(in) || err != nil {
+					t.Fatalf("want %d, nil, got %d, %v", len(in), n, err)
+				}
+			}
+
+			var lines []string
+			if w.Len() > 0 {
+				lines = strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+			}
+			if len(lines) != len(tc.want) {
+				t.Fatalf("want %d lines, got %q", len(tc.want), lines)
+			}
+			for i, line := range lines {
+				pattern := ts + regexp.QuoteMeta(tc.want[i]) + "$"
+				if !regexp.MustCompile(pattern).MatchString(line) {
+					t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterForStdLogger(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	log.New(WriterFor(LevelError), "", 0).Print("Ciao")
+
+	pattern := ts + regexp.QuoteMeta(lp[2]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	sl := l.StdLogger(LevelWarning)
+	sl.Print("Ciao")
+	sl.Printf("fmt: %d\n", 7)
+	sl.Println("one\ntwo")
+
+	want := []string{lp[1] + "Ciao", lp[1] + "fmt: 7", lp[1] + "one", lp[1] + "two"}
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want %d lines, got %q", len(want), lines)
+	}
+	for i, line := range lines {
+		pattern := ts + regexp.QuoteMeta(want[i]) + "$"
+		if !regexp.MustCompile(pattern).MatchString(line) {
+			t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+		}
+	}
+}
+
+func TestDefaultStdLogger(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetDefaultLevel(LevelWarning)
+	sl := l.DefaultStdLogger()
+	l.SetDefaultLevel(LevelError) // the level of sl stays fixed
+	sl.Print("Ciao")
+	sl.Print(lp[2] + "not an error")
+
+	pattern := ts + regexp.QuoteMeta(lp[1]+"Ciao") + "\n" + ts[1:] + regexp.QuoteMeta(lp[1]+lp[2]+"not an error") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestWriterForCaller(t *testing.T) {
+	tt := []struct {
+		name  string
+		short bool // SetCaller instead of Verbose
+		write func(l *Logger) int
+	}{
+		{"write", false, func(l *Logger) int {
+			_, _, line, _ := runtime.Caller(0)
+			l.WriterFor(LevelInfo).Write([]byte("Ciao\n")) // #nosec
+			return line + 1
+		}},
+		{"fmt", false, func(l *Logger) int {
+			_, _, line, _ := runtime.Caller(0)
+			fmt.Fprintln(l.WriterFor(LevelInfo), "Ciao")
+			return line + 1
+		}},
+		{"std logger", false, func(l *Logger) int {
+			_, _, line, _ := runtime.Caller(0)
+			log.New(l.WriterFor(LevelInfo), "", 0).Print("Ciao")
+			return line + 1
+		}},
+		{"ingest", false, func(l *Logger) int {
+			_, _, line, _ := runtime.Caller(0)
+			io.WriteString(l.IngestWriter(LevelInfo), lp[0]+"Ciao\n") // #nosec
+			return line + 1
+		}},
+		{"caller short", true, func(l *Logger) int {
+			_, _, line, _ := runtime.Caller(0)
+			l.WriterFor(LevelInfo).Write([]byte("Ciao\n")) // #nosec
+			return line + 1
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			if tc.short {
+				l.SetCaller(CallerShort)
+			} else {
+				l.Verbose(true)
+			}
+			line := tc.write(l)
+
+			want := "ingest_test.go:" + strconv.Itoa(line) + ": " + lp[0] + "Ciao\n"
+			if !strings.HasSuffix(w.String(), want) {
+				t.Errorf("want suffix %q, got %q", want, w.String())
+			}
+		})
+	}
+}
+
+func TestStdLoggerCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+	sl := l.StdLogger(LevelWarning)
+	_, _, line, _ := runtime.Caller(0)
+	sl.Print("Ciao")
+	sl.Printf("fmt: %d", 7)
+
+	want := "ingest_test.go:" + strconv.Itoa(line+1) + ": " + lp[1] + "Ciao\n"
+	want2 := "ingest_test.go:" + strconv.Itoa(line+2) + ": " + lp[1] + "fmt: 7\n"
+	pattern := ts + regexp.QuoteMeta(want) + ts[1:] + regexp.QuoteMeta(want2) + "$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
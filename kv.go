@@ -0,0 +1,109 @@
+package log
+
+import "fmt"
+
+// Tracew logs a Trace level message with key/value pairs on the standard output,
+// see Logger.Tracew.
+func Tracew(msg string, keysAndValues ...interface{}) {
+	std.Tracew(msg, keysAndValues...)
+}
+
+// Debugw logs a Debug level message with key/value pairs on the standard output,
+// see Logger.Debugw.
+func Debugw(msg string, keysAndValues ...interface{}) {
+	std.Debugw(msg, keysAndValues...)
+}
+
+// Infow logs an Info level message with key/value pairs on the standard output,
+// see Logger.Infow.
+func Infow(msg string, keysAndValues ...interface{}) {
+	std.Infow(msg, keysAndValues...)
+}
+
+// Warnw logs a Warning level message with key/value pairs on the standard output,
+// see Logger.Warnw.
+func Warnw(msg string, keysAndValues ...interface{}) {
+	std.Warnw(msg, keysAndValues...)
+}
+
+// Errorw logs an Error level message with key/value pairs on the standard output,
+// see Logger.Errorw.
+func Errorw(msg string, keysAndValues ...interface{}) {
+	std.Errorw(msg, keysAndValues...)
+}
+
+// Tracew logs a Trace level message followed by the given key/value pairs,
+// see Infow.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func (l *Logger) Tracew(msg string, keysAndValues ...interface{}) {
+	if l.Level() > LevelTrace {
+		return
+	}
+	l.outputFields(LevelTrace, msg, keysAndValues)
+}
+
+// Debugw logs a Debug level message followed by the given key/value pairs,
+// see Infow.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (l *Logger) Debugw(msg string, keysAndValues ...interface{}) {
+	if l.Level() > LevelDebug {
+		return
+	}
+	l.outputFields(LevelDebug, msg, keysAndValues)
+}
+
+// Infow logs an Info level message followed by the given key/value pairs,
+// rendered as " key=value" after the logger fields (see With), replacing
+// those with the same key; a key without a value gets "<missing>" and keys
+// are formatted with fmt.Sprint.
+// Log message is emitted only if the current logging level is equal or less than LevelInfo.
+func (l *Logger) Infow(msg string, keysAndValues ...interface{}) {
+	if l.Level() > LevelInfo {
+		return
+	}
+	l.outputFields(LevelInfo, msg, keysAndValues)
+}
+
+// Warnw logs a Warning level message followed by the given key/value pairs,
+// see Infow.
+// Log message is emitted only if the current logging level is equal or less than LevelWarning.
+func (l *Logger) Warnw(msg string, keysAndValues ...interface{}) {
+	if l.Level() > LevelWarning {
+		return
+	}
+	l.outputFields(LevelWarning, msg, keysAndValues)
+}
+
+// Errorw logs an Error level message followed by the given key/value pairs,
+// see Infow.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
+func (l *Logger) Errorw(msg string, keysAndValues ...interface{}) {
+	if l.Level() > LevelError {
+		return
+	}
+	l.outputFields(LevelError, msg, keysAndValues)
+}
+
+// outputFields is like output, adding the fields of the key/value pairs kv.
+// It must be called directly by the logging methods for the call depth to hold.
+func (l *Logger) outputFields(level Severity, s string, kv []interface{}) {
+	l.outputFieldsDepth(l.calldepth+1, level, s, kv)
+}
+
+// outputFieldsDepth is like outputFields, calldepth being relative to it as
+// for emit.
+func (l *Logger) outputFieldsDepth(calldepth int, level Severity, s string, kv []interface{}) {
+	extra := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		f := Field{Key: fmt.Sprint(kv[i]), Value: "<missing>"}
+		if i+1 < len(kv) {
+			f.Value = kv[i+1]
+		}
+		extra = append(extra, f)
+	}
+
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(calldepth+1, 0, level, "", s, extra)
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestKeysAndValues(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+		want string
+	}{
+		{"no pairs", func(l *Logger) { l.Infow("Ciao") }, lp[0] + "Ciao"},
+		{"balanced", func(l *Logger) { l.Infow("Ciao", "id", 7, "user", "bob") }, lp[0] + "Ciao id=7 user=bob"},
+		{"odd", func(l *Logger) { l.Warnw("Ciao", "id", 7, "user") }, lp[1] + "Ciao id=7 user=<missing>"},
+		{"non-string keys", func(l *Logger) { l.Errorw("Ciao", 1, "a", errors.New("k"), 2.5) }, lp[2] + "Ciao 1=a k=2.5"},
+		{"unsorted", func(l *Logger) { l.Infow("Ciao", "b", 2, "a", 1) }, lp[0] + "Ciao b=2 a=1"},
+		{"after logger fields", func(l *Logger) { l.With("a", 1).Infow("Ciao", "b", 2) }, lp[0] + "Ciao a=1 b=2"},
+		{"debug", func(l *Logger) { l.SetLevel(LevelTrace); l.Debugw("Ciao", "a", 1) }, "DEBUG> Ciao a=1"},
+		{"trace", func(l *Logger) { l.SetLevel(LevelTrace); l.Tracew("Ciao", "a", 1) }, "TRACE> Ciao a=1"},
+		{"disabled", func(l *Logger) { l.Debugw("Ciao", "a", 1) }, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			tc.f(l)
+
+			pattern := "^$"
+			if tc.want != "" {
+				pattern = ts + regexp.QuoteMeta(tc.want) + "\n$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestKeysAndValuesPackageLevel(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	SetCallerMinLevel(LevelInfo)
+	defer SetCallerMinLevel(LevelOff)
+	Infow("Ciao", "a", 1)
+
+	pattern := ts + "kv_test.go:[0-9]+: " + regexp.QuoteMeta(lp[0]+"Ciao a=1") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
@@ -0,0 +1,76 @@
+package log
+
+// TraceFunc logs a Trace level message on the standard output, see Logger.TraceFunc.
+func TraceFunc(fn func() string) {
+	std.TraceFunc(fn)
+}
+
+// DebugFunc logs a Debug level message on the standard output, see Logger.DebugFunc.
+func DebugFunc(fn func() string) {
+	std.DebugFunc(fn)
+}
+
+// InfoFunc logs an Info level message on the standard output, see Logger.InfoFunc.
+func InfoFunc(fn func() string) {
+	std.InfoFunc(fn)
+}
+
+// WarningFunc logs a Warning level message on the standard output, see Logger.WarningFunc.
+func WarningFunc(fn func() string) {
+	std.WarningFunc(fn)
+}
+
+// ErrorFunc logs an Error level message on the standard output, see Logger.ErrorFunc.
+func ErrorFunc(fn func() string) {
+	std.ErrorFunc(fn)
+}
+
+// TraceFunc logs a Trace level message with the text returned by fn, which is only
+// called if the message is printed, to defer building a costly text.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func (l *Logger) TraceFunc(fn func() string) {
+	if l.Level() > LevelTrace {
+		return
+	}
+	l.output(LevelTrace, fn())
+}
+
+// DebugFunc logs a Debug level message with the text returned by fn, which is only
+// called if the message is printed, to defer building a costly text.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (l *Logger) DebugFunc(fn func() string) {
+	if l.Level() > LevelDebug {
+		return
+	}
+	l.output(LevelDebug, fn())
+}
+
+// InfoFunc logs an Info level message with the text returned by fn, which is only
+// called if the message is printed, to defer building a costly text.
+// Log message is emitted only if the current logging level is equal or less than LevelInfo.
+func (l *Logger) InfoFunc(fn func() string) {
+	if l.Level() > LevelInfo {
+		return
+	}
+	l.output(LevelInfo, fn())
+}
+
+// WarningFunc logs a Warning level message with the text returned by fn, which is only
+// called if the message is printed, to defer building a costly text.
+// Log message is emitted only if the current logging level is equal or less than LevelWarning.
+func (l *Logger) WarningFunc(fn func() string) {
+	if l.Level() > LevelWarning {
+		return
+	}
+	l.output(LevelWarning, fn())
+}
+
+// ErrorFunc logs an Error level message with the text returned by fn, which is only
+// called if the message is printed, to defer building a costly text.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
+func (l *Logger) ErrorFunc(fn func() string) {
+	if l.Level() > LevelError {
+		return
+	}
+	l.output(LevelError, fn())
+}
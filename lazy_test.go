@@ -0,0 +1,83 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestFunc(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		f     func(l *Logger, fn func() string)
+		want  string
+	}{
+		{"TraceFunc", LevelTrace, func(l *Logger, fn func() string) { l.TraceFunc(fn) }, "TRACE> Ciao"},
+		{"DebugFunc", LevelDebug, func(l *Logger, fn func() string) { l.DebugFunc(fn) }, "DEBUG> Ciao"},
+		{"InfoFunc", LevelInfo, func(l *Logger, fn func() string) { l.InfoFunc(fn) }, lp[0] + "Ciao"},
+		{"WarningFunc", LevelWarning, func(l *Logger, fn func() string) { l.WarningFunc(fn) }, lp[1] + "Ciao"},
+		{"ErrorFunc", LevelError, func(l *Logger, fn func() string) { l.ErrorFunc(fn) }, lp[2] + "Ciao"},
+		{"InfoFunc disabled", LevelWarning, func(l *Logger, fn func() string) { l.InfoFunc(fn) }, ""},
+		{"DebugFunc disabled", LevelInfo, func(l *Logger, fn func() string) { l.DebugFunc(fn) }, ""},
+		{"ErrorFunc off", LevelOff, func(l *Logger, fn func() string) { l.ErrorFunc(fn) }, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.level, WithWriter(w))
+			called := 0
+			tc.f(l, func() string { called++; return "Ciao" })
+
+			calls, pattern := 0, "^$"
+			if tc.want != "" {
+				calls, pattern = 1, ts+regexp.QuoteMeta(tc.want)+"\n$"
+			}
+			if called != calls {
+				t.Errorf("closure called %d times, want %d", called, calls)
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+// countStringer counts the calls to String.
+type countStringer struct {
+	n int
+}
+
+func (s *countStringer) String() string {
+	s.n++
+	return "Ciao"
+}
+
+func TestDisabledNotFormatted(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(l *Logger, v interface{})
+	}{
+		{"Debug", func(l *Logger, v interface{}) { l.Debug(v) }},
+		{"Debugf", func(l *Logger, v interface{}) { l.Debugf("%v", v) }},
+		{"Debugln", func(l *Logger, v interface{}) { l.Debugln(v) }},
+		{"Trace", func(l *Logger, v interface{}) { l.Trace(v) }},
+		{"Tracef", func(l *Logger, v interface{}) { l.Tracef("%v", v) }},
+		{"Traceln", func(l *Logger, v interface{}) { l.Traceln(v) }},
+		{"Print", func(l *Logger, v interface{}) { l.SetDefaultLevel(LevelDebug); l.Print(v) }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			s := new(countStringer)
+			tc.f(l, s)
+
+			if s.n != 0 || w.Len() != 0 {
+				t.Errorf("disabled message formatted %d times, got %q", s.n, w.String())
+			}
+		})
+	}
+}
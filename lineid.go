@@ -0,0 +1,55 @@
+package log
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+)
+
+// lineIDEncoding encodes line IDs in a compact, case insensitive form.
+var lineIDEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// SetLineID enables or disables (default) a random ID on every line.
+func SetLineID(v bool) {
+	std.SetLineID(v)
+}
+
+// LogfID logs a message with a random line ID and returns the ID.
+func LogfID(level Severity, format string, v ...interface{}) string {
+	return std.LogfID(level, format, v...)
+}
+
+// SetLineID enables or disables (default) a random ID on every line, printed
+// after the level label as "[id] ", to reference the line from elsewhere
+// (e.g. a ticket or a metric exemplar).
+func (l *Logger) SetLineID(v bool) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.lineID = v
+}
+
+// LogfID logs a message of the given level with a random line ID, whether
+// SetLineID is enabled or not, and returns the ID.
+// Arguments are handled in the manner of fmt.Printf.
+// Nothing is logged and an empty string is returned if the level is disabled.
+func (l *Logger) LogfID(level Severity, format string, v ...interface{}) string {
+	if !l.Enabled(level) {
+		return ""
+	}
+
+	id := newLineID()
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(l.calldepth, 0, level, id, fmt.Sprintf(format, v...), nil)
+	return id
+}
+
+// newLineID returns a random 13 characters ID.
+func newLineID() string {
+	var b [8]byte
+	rand.Read(b[:]) // #nosec
+	return strings.ToLower(lineIDEncoding.EncodeToString(b[:]))
+}
@@ -0,0 +1,95 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLineID(t *testing.T) {
+	const id = `\[[a-z2-7]{13}\] `
+
+	tt := []struct {
+		name    string
+		enabled bool
+		f       func(l *Logger)
+		pattern string
+	}{
+		{"disabled", false, func(l *Logger) { l.Info("Ciao") }, ts + lp[0] + "Ciao\n$"},
+		{"info", true, func(l *Logger) { l.Info("Ciao") }, ts + lp[0] + id + "Ciao\n$"},
+		{"errorf", true, func(l *Logger) { l.Errorf("%s", "Ciao") }, ts + lp[2] + id + "Ciao\n$"},
+		{"table", true, func(l *Logger) { l.Table(LevelWarning, map[string]string{"k": "v"}) }, ts + lp[1] + id + "k  v\n$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetLineID(tc.enabled)
+			tc.f(l)
+
+			if !regexp.MustCompile(tc.pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", tc.pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestLineIDUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		id := newLineID()
+		if seen[id] {
+			t.Fatalf("duplicate line ID %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestLogfID(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		w := new(bytes.Buffer)
+		l := New(LevelInfo)
+		l.SetWriter(w)
+		l.SetLineID(enabled)
+		id := l.LogfID(LevelWarning, "fmt: %s %v", "ciao", 7)
+
+		if len(id) != 13 {
+			t.Fatalf("unexpected ID %q", id)
+		}
+		pattern := ts + lp[1] + regexp.QuoteMeta("["+id+"] ") + "fmt: ciao 7\n$"
+		if !regexp.MustCompile(pattern).MatchString(w.String()) {
+			t.Errorf("line ID enabled %v: mismatch! Pattern %q, got %q", enabled, pattern, w.String())
+		}
+	}
+
+	l := New(LevelError)
+	l.SetWriter(new(bytes.Buffer))
+	if id := l.LogfID(LevelInfo, "Ciao"); id != "" {
+		t.Errorf("want no ID for a disabled level, got %q", id)
+	}
+}
+
+func TestLineIDMaxMessageLength(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetMaxMessageLength(4)
+	id := l.LogfID(LevelInfo, "Ciao %s", "mondo")
+	l.SetLineID(true)
+	l.Info("Hello")
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %q", lines)
+	}
+	pattern := ts + regexp.QuoteMeta(lp[0]+"["+id+"] Ciao"+truncated) + "$"
+	if !regexp.MustCompile(pattern).MatchString(lines[0]) {
+		t.Errorf("LogfID: mismatch! Pattern %q, got %q", pattern, lines[0])
+	}
+	pattern = ts + regexp.QuoteMeta(lp[0]+"[") + "[a-z2-7]{13}" + regexp.QuoteMeta("] Hell"+truncated) + "$"
+	if !regexp.MustCompile(pattern).MatchString(lines[1]) {
+		t.Errorf("SetLineID: mismatch! Pattern %q, got %q", pattern, lines[1])
+	}
+}
@@ -2,45 +2,210 @@
 package log
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 )
 
 // Standard flags for no verbose logging.
 const stdFlags = log.LstdFlags | log.Lmicroseconds
 
 // Available logging levels.
+// LevelInfo is the zero value, lower levels are negative.
 const (
-	LevelInfo    Severity = iota // Lower
-	LevelWarning                 // Medium
-	LevelError                   // High
+	LevelTrace   Severity = iota - 2 // Lowest
+	LevelDebug                       // Lower
+	LevelInfo                        // Low
+	LevelWarning                     // Medium
+	LevelError                       // High
+	LevelOff                         // Disables all output
 )
 
 // Severity represents logging level.
 type Severity int
 
+// String returns the name of the level, e.g. "INFO", or "Severity(n)" for
+// an unknown level.
+func (s Severity) String() string {
+	if name, ok := names[s]; ok {
+		return name
+	}
+	return "Severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+var names = map[Severity]string{LevelTrace: "TRACE", LevelDebug: "DEBUG", LevelInfo: "INFO", LevelWarning: "WARN", LevelError: "ERROR", LevelOff: "OFF"}
+
+// MarshalText implements encoding.TextMarshaler, using the level name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseLevel.
+func (s *Severity) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*s = level
+	return nil
+}
+
+// ParseLevel returns the level named s, as returned by Severity.String,
+// regardless of case and surrounding white space. "WARNING" is also accepted.
+func ParseLevel(s string) (Severity, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "WARNING") {
+		return LevelWarning, nil
+	}
+	for level, name := range names {
+		if strings.EqualFold(name, s) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("log: unknown level %q", s)
+}
+
+// Levels returns all the defined logging levels in ascending order.
+func Levels() []Severity {
+	return []Severity{LevelTrace, LevelDebug, LevelInfo, LevelWarning, LevelError}
+}
+
 // Logger is the logger structure.
 type Logger struct {
-	out       *log.Logger
-	level     Severity
-	calldepth int
+	out       *output
+	hdrFlags  atomic.Int64 // flags of the line header, see applyFlags
+	level     atomic.Int64 // Severity
+	calldepth int          // see SetCallDepth, guarded by omu
+	callerMin atomic.Int64 // Severity
+	firstLeft atomic.Int64 // lines left to print verbose, see VerboseForFirst
+	defLevel  atomic.Int64 // Severity of Print, see SetDefaultLevel
+	maxDepth  atomic.Int64 // see SetMaxStructDepth
+
+	mu          sync.Mutex // guards flags, fatalPolicy, ctxFields, traceIDs, omitted, errFormat, onLevel, beats and aggs
+	flags       int
+	fatalPolicy func(msg string) bool
+	ctxFields   func(context.Context) []Field
+	traceIDs    func(context.Context) (traceID, spanID string)
+	omitted     map[string]bool // see SetOmittedHeaders, the defaults if nil
+	errFormat   func(error) string
+	onLevel     []func(old, new Severity)
+	beats       map[*heartbeat]struct{}
+	aggs        map[string]*aggregate
+	aggLevel    Severity
+
+	omu        *sync.Mutex // serializes output so grouped lines stay contiguous, shared by the derived loggers
+	linePrefix string      // see SetLineWrap
+	suffix     string
+	newline    string // see SetNewline
+	escapeNL   bool   // see SetEscapeNewlines
+	lineID     bool
+	skipEmpty  bool
+	fieldPos   FieldPosition
+	maxLen     int                 // see SetMaxMessageLength
+	prefixes   map[Severity]string // level labels, the defaults if nil
+	tag        string
+	fields     []Field               // see With and SetGlobalFields
+	fieldText  string                // fields rendered as " key=value" pairs
+	formatter  Formatter             // also guarded by mu, to apply the flags
+	errOut     *output               // for the messages from errMin up if set
+	errMin     Severity              // see SetStderrMinLevel
+	levelOut   map[Severity]*output  // see SetLevelWriter
+	samplers   map[Severity]*sampler // see SetLevelSampler
+	color      ColorMode
+	ttyFile    *os.File // last writer checked by ColorAuto
+	tty        bool     // whether ttyFile is a terminal
+	pause      *pauseBuffer
+	async      *asyncWriter
+	sys        levelWriter // see SetSyslog
+	hooks      []Hook
+	recSinks   []RecordSink // see AddRecordSink
+	ring       *ringBuffer  // see EnableRingBuffer
+	firings    []firing     // hooks to fire once omu is released, see unlock
+	redactors  []redactor
+	fieldFn    func(key string, value interface{}) (string, interface{}) // see SetFieldTransformer
+	pauseMax   int
+
+	timeFormat string // see SetTimeFormat, also guarded by mu
+	customTime bool   // whether timeFormat replaces the standard timestamp
 }
 
 // New instantiates a new Logger.
 // level is the minimum logging level message to be printed.
-// By default all logs are printed on standard output.
-func New(level Severity) *Logger {
-	return &Logger{
-		out:       log.New(os.Stdout, "", stdFlags),
-		level:     level,
+// By default all logs are printed on standard output; opts, such as
+// WithWriter, are applied in order to change the defaults.
+func New(level Severity, opts ...Option) *Logger {
+	l := &Logger{
+		out:       newOutput(os.Stdout),
+		omu:       new(sync.Mutex),
 		calldepth: 2,
+		flags:     stdFlags,
+		newline:   "\n",
+		errMin:    LevelError,
+		pauseMax:  defaultPauseMax,
+	}
+	l.hdrFlags.Store(stdFlags)
+	l.level.Store(int64(level))
+	l.callerMin.Store(int64(LevelOff))
+	l.defLevel.Store(int64(LevelInfo))
+	for _, opt := range opts {
+		opt(l)
 	}
+	return l
 }
 
 var std = newStd()
 
+// Trace logs a Trace level message on the standard output.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func Trace(v ...interface{}) {
+	std.Trace(v...)
+}
+
+// Tracef logs a Trace level message on the standard output.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func Tracef(format string, v ...interface{}) {
+	std.Tracef(format, v...)
+}
+
+// Traceln logs a Trace level message on the standard output.
+// Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func Traceln(v ...interface{}) {
+	std.Traceln(v...)
+}
+
+// Debug logs a Debug level message on the standard output.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func Debug(v ...interface{}) {
+	std.Debug(v...)
+}
+
+// Debugf logs a Debug level message on the standard output.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func Debugf(format string, v ...interface{}) {
+	std.Debugf(format, v...)
+}
+
+// Debugln logs a Debug level message on the standard output.
+// Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func Debugln(v ...interface{}) {
+	std.Debugln(v...)
+}
+
 // Info logs an Info level message on the standard output.
 // Arguments are handled in the manner of fmt.Print.
 // Log message is emitted only if the current logging level is equal or less than LevelInfo.
@@ -85,18 +250,21 @@ func Warningln(v ...interface{}) {
 
 // Error logs an Error level message on the standard error.
 // Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
 func Error(v ...interface{}) {
 	std.Error(v...)
 }
 
 // Errorf logs an Error level message on the standard error.
 // Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
 func Errorf(format string, v ...interface{}) {
 	std.Errorf(format, v...)
 }
 
 // Errorln logs an Error level message on the standard error.
 // Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
 func Errorln(v ...interface{}) {
 	std.Errorln(v...)
 }
@@ -119,16 +287,146 @@ func Fatalln(v ...interface{}) {
 	std.Fatalln(v...)
 }
 
+// Panic logs an Error level message on the standard output and panics with it.
+// Arguments are handled in the manner of fmt.Print.
+func Panic(v ...interface{}) {
+	std.Panic(v...)
+}
+
+// Panicf logs an Error level message on the standard output and panics with it.
+// Arguments are handled in the manner of fmt.Printf.
+func Panicf(format string, v ...interface{}) {
+	std.Panicf(format, v...)
+}
+
+// Panicln logs an Error level message on the standard output and panics with it.
+// Arguments are handled in the manner of fmt.Println.
+func Panicln(v ...interface{}) {
+	std.Panicln(v...)
+}
+
+// SetFatalPolicy sets a function deciding whether Fatal, Fatalf and Fatalln
+// exit, see Logger.SetFatalPolicy.
+func SetFatalPolicy(fn func(msg string) bool) {
+	std.SetFatalPolicy(fn)
+}
+
 // Verbose selects between short or verbose prefix (currently adds file and line number).
 func Verbose(v bool) {
 	std.Verbose(v)
 }
 
+// SetCaller selects how the standard logger reports the caller, see Logger.SetCaller.
+func SetCaller(mode CallerMode) {
+	std.SetCaller(mode)
+}
+
+// SetCallerMinLevel adds file and line number to messages of level min or higher,
+// regardless of Verbose. LevelOff disables it (default).
+func SetCallerMinLevel(min Severity) {
+	std.SetCallerMinLevel(min)
+}
+
+// SetCallDepth sets the call depth of the standard logger, see Logger.SetCallDepth.
+func SetCallDepth(n int) {
+	std.SetCallDepth(n)
+}
+
+// CallDepth returns the call depth of the standard logger, see Logger.CallDepth.
+func CallDepth() int {
+	return std.CallDepth()
+}
+
+// VerboseForFirst adds file and line number to the next n messages only.
+func VerboseForFirst(n int) {
+	std.VerboseForFirst(n)
+}
+
+// SetUTC selects between local time (default) or UTC in timestamps.
+func SetUTC(v bool) {
+	std.SetUTC(v)
+}
+
+// SetMicroseconds enables (default) or disables microseconds resolution in timestamps.
+func SetMicroseconds(v bool) {
+	std.SetMicroseconds(v)
+}
+
+// SetTimestamp enables (default) or disables the timestamp in front of every message.
+func SetTimestamp(v bool) {
+	std.SetTimestamp(v)
+}
+
+// SetTimeFormat sets the layout of the standard logger timestamps, see Logger.SetTimeFormat.
+func SetTimeFormat(layout string) {
+	std.SetTimeFormat(layout)
+}
+
+// SetTimeFormat renders the timestamp with the given time.Format layout,
+// e.g. time.RFC3339, instead of the standard one which the log.Logger
+// flags are limited to; SetMicroseconds has then no effect.
+// An empty layout disables the timestamp, SetTimestamp restores the standard one.
+func (l *Logger) SetTimeFormat(layout string) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.timeFormat = layout
+	l.customTime = true
+	l.applyFlags()
+}
+
+// SetLineWrap sets strings written before and after every line.
+func SetLineWrap(prefix, suffix string) {
+	std.SetLineWrap(prefix, suffix)
+}
+
+// SetNewline sets the line terminator of the standard logger, see
+// Logger.SetNewline.
+func SetNewline(s string) {
+	std.SetNewline(s)
+}
+
+// SetEscapeNewlines enables or disables (default) the escaping of the
+// newlines of the standard logger messages, see Logger.SetEscapeNewlines.
+func SetEscapeNewlines(v bool) {
+	std.SetEscapeNewlines(v)
+}
+
+// SetSkipEmpty enables or disables (default) dropping messages with an empty text.
+func SetSkipEmpty(v bool) {
+	std.SetSkipEmpty(v)
+}
+
+// SetMaxMessageLength sets the length the standard logger truncates message
+// texts to, see Logger.SetMaxMessageLength.
+func SetMaxMessageLength(n int) {
+	std.SetMaxMessageLength(n)
+}
+
 // SetLevel selects the minimum logging level to print.
 func SetLevel(level Severity) {
 	std.SetLevel(level)
 }
 
+// OnLevelChange registers a callback for the standard logger level changes,
+// see Logger.OnLevelChange.
+func OnLevelChange(fn func(old, new Severity)) {
+	std.OnLevelChange(fn)
+}
+
+// Enabled reports whether a message of the given level would be printed on
+// the standard output, see Logger.Enabled.
+func Enabled(level Severity) bool {
+	return std.Enabled(level)
+}
+
+// V reports whether verbosity depth n is enabled, see Logger.V.
+func V(n int) bool {
+	return std.V(n)
+}
+
 // Level returns the log level currently set.
 func Level() Severity {
 	return std.Level()
@@ -144,137 +442,967 @@ func Writer() io.Writer {
 	return std.Writer()
 }
 
-var prefix = [...]string{LevelInfo: "INFO> ", LevelWarning: "WARN> ", LevelError: "ERROR> "}
+// SetErrorWriter sets the standard logger output stream for Error and Fatal
+// messages, see Logger.SetErrorWriter.
+func SetErrorWriter(w io.Writer) {
+	std.SetErrorWriter(w)
+}
+
+// SetLevelWriter sets the standard logger output stream for the messages of
+// the given level, see Logger.SetLevelWriter.
+func SetLevelWriter(level Severity, w io.Writer) {
+	std.SetLevelWriter(level, w)
+}
+
+// SetStderrMinLevel sets the minimum level of the messages going to the error
+// writer of the standard logger, see Logger.SetStderrMinLevel.
+func SetStderrMinLevel(min Severity) {
+	std.SetStderrMinLevel(min)
+}
+
+// ErrorWriter returns the standard logger output stream for Error and Fatal
+// messages.
+func ErrorWriter() io.Writer {
+	return std.ErrorWriter()
+}
+
+// Prefix returns the label printed by the standard logger in front of
+// messages of the given level, see Logger.Prefix.
+func Prefix(level Severity) string {
+	return std.Prefix(level)
+}
+
+// Tag returns the tag printed by the standard logger in front of every message.
+func Tag() string {
+	return std.Tag()
+}
+
+// SetTag sets the tag printed by the standard logger in front of every
+// message, see Logger.SetTag.
+func SetTag(tag string) {
+	std.SetTag(tag)
+}
+
+// SetPrefix sets the label printed by the standard logger in front of
+// messages of the given level, see Logger.SetPrefix.
+func SetPrefix(level Severity, p string) {
+	std.SetPrefix(level, p)
+}
+
+// SetPrefixes sets the labels printed by the standard logger in front of
+// messages of the levels in prefixes, see Logger.SetPrefix.
+func SetPrefixes(prefixes map[Severity]string) {
+	std.SetPrefixes(prefixes)
+}
+
+var prefix = map[Severity]string{LevelTrace: "TRACE> ", LevelDebug: "DEBUG> ", LevelInfo: "INFO> ", LevelWarning: "WARN> ", LevelError: "ERROR> "}
+
+// Trace logs a Trace level message on the standard output.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func (l *Logger) Trace(v ...interface{}) {
+	if l.Level() > LevelTrace {
+		return
+	}
+	l.output(LevelTrace, fmt.Sprint(v...))
+}
+
+// Tracef logs a Trace level message on the standard output.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func (l *Logger) Tracef(format string, v ...interface{}) {
+	if l.Level() > LevelTrace {
+		return
+	}
+	l.output(LevelTrace, fmt.Sprintf(format, v...))
+}
+
+// Traceln logs a Trace level message on the standard output.
+// Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than LevelTrace.
+func (l *Logger) Traceln(v ...interface{}) {
+	if l.Level() > LevelTrace {
+		return
+	}
+	l.output(LevelTrace, sprintln(v...))
+}
+
+// Debug logs a Debug level message on the standard output.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (l *Logger) Debug(v ...interface{}) {
+	if l.Level() > LevelDebug {
+		return
+	}
+	l.output(LevelDebug, fmt.Sprint(v...))
+}
+
+// Debugf logs a Debug level message on the standard output.
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	if l.Level() > LevelDebug {
+		return
+	}
+	l.output(LevelDebug, fmt.Sprintf(format, v...))
+}
+
+// Debugln logs a Debug level message on the standard output.
+// Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than LevelDebug.
+func (l *Logger) Debugln(v ...interface{}) {
+	if l.Level() > LevelDebug {
+		return
+	}
+	l.output(LevelDebug, sprintln(v...))
+}
 
 // Info logs an Info level message on the standard output.
 // Arguments are handled in the manner of fmt.Print.
 // Log message is emitted only if the current logging level is equal or less than LevelInfo.
 func (l *Logger) Info(v ...interface{}) {
-	if l.level > LevelInfo {
+	if l.Level() > LevelInfo {
 		return
 	}
-	l.out.Output(l.calldepth, prefix[LevelInfo]+fmt.Sprint(v...)) // #nosec
+	l.output(LevelInfo, fmt.Sprint(v...))
 }
 
 // Infof logs an Info level message on the standard output.
 // Arguments are handled in the manner of fmt.Printf.
 // Log message is emitted only if the current logging level is equal or less than LevelInfo.
 func (l *Logger) Infof(format string, v ...interface{}) {
-	if l.level > LevelInfo {
+	if l.Level() > LevelInfo {
 		return
 	}
-	l.out.Output(l.calldepth, fmt.Sprintf(prefix[LevelInfo]+format, v...)) // #nosec
+	l.output(LevelInfo, fmt.Sprintf(format, v...))
 }
 
 // Infoln logs an Info level message on the standard output.
 // Arguments are handled in the manner of fmt.Println.
 // Log message is emitted only if the current logging level is equal or less than LevelInfo.
 func (l *Logger) Infoln(v ...interface{}) {
-	if l.level > LevelInfo {
+	if l.Level() > LevelInfo {
 		return
 	}
-	l.out.Output(l.calldepth, prefix[LevelInfo]+fmt.Sprintln(v...)) // #nosec
+	l.output(LevelInfo, sprintln(v...))
 }
 
 // Warning logs a Warning level message on the standard output.
 // Arguments are handled in the manner of fmt.Print.
 // Log message is emitted only if the current logging level is equal or less than LevelWarning.
 func (l *Logger) Warning(v ...interface{}) {
-	if l.level > LevelWarning {
+	if l.Level() > LevelWarning {
 		return
 	}
-	l.out.Output(l.calldepth, prefix[LevelWarning]+fmt.Sprint(v...)) // #nosec
+	l.output(LevelWarning, fmt.Sprint(v...))
 }
 
 // Warningf logs a Warning level message on the standard output.
 // Arguments are handled in the manner of fmt.Printf.
 // Log message is emitted only if the current logging level is equal or less than LevelWarning.
 func (l *Logger) Warningf(format string, v ...interface{}) {
-	if l.level > LevelWarning {
+	if l.Level() > LevelWarning {
 		return
 	}
-	l.out.Output(l.calldepth, fmt.Sprintf(prefix[LevelWarning]+format, v...)) // #nosec
+	l.output(LevelWarning, fmt.Sprintf(format, v...))
 }
 
 // Warningln logs a Warning level message on the standard output.
 // Arguments are handled in the manner of fmt.Println.
 // Log message is emitted only if the current logging level is equal or less than LevelWarning.
 func (l *Logger) Warningln(v ...interface{}) {
-	if l.level > LevelWarning {
+	if l.Level() > LevelWarning {
 		return
 	}
-	l.out.Output(l.calldepth, prefix[LevelWarning]+fmt.Sprintln(v...)) // #nosec
+	l.output(LevelWarning, sprintln(v...))
 }
 
 // Error logs an Error level message on the standard error.
 // Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
 func (l *Logger) Error(v ...interface{}) {
-	l.out.Output(l.calldepth, prefix[LevelError]+fmt.Sprint(v...)) // #nosec
+	if l.Level() > LevelError {
+		return
+	}
+	l.output(LevelError, fmt.Sprint(l.formatErrors(v)...))
 }
 
 // Errorf logs an Error level message on the standard error.
 // Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.out.Output(l.calldepth, fmt.Sprintf(prefix[LevelError]+format, v...)) // #nosec
+	if l.Level() > LevelError {
+		return
+	}
+	l.output(LevelError, fmt.Sprintf(format, l.formatErrors(v)...))
 }
 
 // Errorln logs an Error level message on the standard error.
 // Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than LevelError.
 func (l *Logger) Errorln(v ...interface{}) {
-	l.out.Output(l.calldepth, prefix[LevelError]+fmt.Sprintln(v...)) // #nosec
+	if l.Level() > LevelError {
+		return
+	}
+	l.output(LevelError, sprintln(l.formatErrors(v)...))
 }
 
 // Fatal logs an Error level message on the standard error and calls os.Exit(1).
 // Arguments are handled in the manner of fmt.Print.
 func (l *Logger) Fatal(v ...interface{}) {
-	l.out.Output(l.calldepth, prefix[LevelError]+fmt.Sprint(v...)) // #nosec
-	os.Exit(1)
+	msg := fmt.Sprint(v...)
+	if l.Level() <= LevelError {
+		l.output(LevelError, msg)
+	}
+	l.exit(msg)
 }
 
 // Fatalf logs an Error level message on the standard error and calls os.Exit(1).
 // Arguments are handled in the manner of fmt.Printf.
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.out.Output(l.calldepth, fmt.Sprintf(prefix[LevelError]+format, v...)) // #nosec
-	os.Exit(1)
+	msg := fmt.Sprintf(format, v...)
+	if l.Level() <= LevelError {
+		l.output(LevelError, msg)
+	}
+	l.exit(msg)
 }
 
 // Fatalln logs an Error level message on the standard error and calls os.Exit(1).
 // Arguments are handled in the manner of fmt.Println.
 func (l *Logger) Fatalln(v ...interface{}) {
-	l.out.Output(l.calldepth, prefix[LevelError]+fmt.Sprintln(v...)) // #nosec
+	msg := sprintln(v...)
+	if l.Level() <= LevelError {
+		l.output(LevelError, msg)
+	}
+	l.exit(msg)
+}
+
+// Panic logs an Error level message and panics with the message text, so
+// that a deferred recover, e.g. in an HTTP middleware, gets it back.
+// Arguments are handled in the manner of fmt.Print.
+func (l *Logger) Panic(v ...interface{}) {
+	msg := fmt.Sprint(v...)
+	if l.Level() <= LevelError {
+		l.output(LevelError, msg)
+	}
+	panic(msg)
+}
+
+// Panicf logs an Error level message and panics with the message text.
+// Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Panicf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	if l.Level() <= LevelError {
+		l.output(LevelError, msg)
+	}
+	panic(msg)
+}
+
+// Panicln logs an Error level message and panics with the message text.
+// Arguments are handled in the manner of fmt.Println.
+func (l *Logger) Panicln(v ...interface{}) {
+	msg := sprintln(v...)
+	if l.Level() <= LevelError {
+		l.output(LevelError, msg)
+	}
+	panic(msg)
+}
+
+// SetFatalPolicy sets a function deciding whether Fatal, Fatalf and Fatalln
+// call os.Exit(1) after logging msg. When fn returns false they return like
+// Error does. A nil fn restores the default of always exiting.
+func (l *Logger) SetFatalPolicy(fn func(msg string) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.fatalPolicy = fn
+}
+
+// exit calls os.Exit(1) unless the fatal policy vetoes it, after flushing
+// the output and syncing the file sinks so that the last lines are not lost.
+func (l *Logger) exit(msg string) {
+	l.mu.Lock()
+	policy := l.fatalPolicy
+	l.mu.Unlock()
+
+	if policy != nil && !policy(msg) {
+		return
+	}
+	l.Flush()     // #nosec
+	l.syncSinks() // #nosec
 	os.Exit(1)
 }
 
 // Verbose selects between short or verbose prefix (currently adds file and line number).
+// It is the same as SetCaller(CallerShort) or SetCaller(CallerNone).
 func (l *Logger) Verbose(v bool) {
-	flags := stdFlags
 	if v {
-		flags |= log.Lshortfile
+		l.SetCaller(CallerShort)
+	} else {
+		l.SetCaller(CallerNone)
+	}
+}
+
+// CallerMode selects how the caller is reported, see SetCaller.
+type CallerMode int
+
+// Available caller modes.
+const (
+	CallerNone  CallerMode = iota // no caller (default)
+	CallerShort                   // base file name and line, as log.Lshortfile
+	CallerLong                    // full file path and line, as log.Llongfile
+)
+
+// SetCaller selects how the caller file and line are added to every message.
+// CallerLong tells apart files with the same name in different packages.
+// The mode also applies to the messages getting the caller regardless,
+// see SetCallerMinLevel and VerboseForFirst.
+func (l *Logger) SetCaller(mode CallerMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.flags &^= log.Lshortfile | log.Llongfile
+	switch mode {
+	case CallerShort:
+		l.flags |= log.Lshortfile
+	case CallerLong:
+		l.flags |= log.Llongfile
+	}
+	l.applyFlags()
+}
+
+// SetCallerMinLevel adds file and line number to messages of level min or higher,
+// regardless of Verbose. LevelOff disables it (default).
+// Caller information is only resolved for the messages needing it.
+func (l *Logger) SetCallerMinLevel(min Severity) {
+	l.callerMin.Store(int64(min))
+}
+
+// SetCallDepth sets the number of stack frames from the function reporting
+// the caller to the code calling the logger: 2 for a Logger, whose methods
+// are called directly, and 3 for the standard logger, as the package-level
+// functions add a call in between. A helper wrapping the logger should add
+// its own frames, e.g. l.SetCallDepth(l.CallDepth()+1), for the caller
+// reported to be the code calling the helper.
+func (l *Logger) SetCallDepth(n int) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.calldepth = n
+}
+
+// CallDepth returns the call depth set by SetCallDepth.
+func (l *Logger) CallDepth() int {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	return l.calldepth
+}
+
+// VerboseForFirst adds file and line number to the next n messages printed,
+// whatever their level, then goes back to the configured verbosity;
+// e.g. to get detailed startup logs only. It restarts the count on every call.
+func (l *Logger) VerboseForFirst(n int) {
+	l.firstLeft.Store(int64(n))
+}
+
+// SetUTC selects between local time (default) or UTC in timestamps,
+// including those rendered by SetTimeFormat and by a formatter.
+func (l *Logger) SetUTC(v bool) {
+	l.setFlags(log.LUTC, v)
+}
+
+// SetMicroseconds enables (default) or disables microseconds resolution in timestamps.
+func (l *Logger) SetMicroseconds(v bool) {
+	l.setFlags(log.Lmicroseconds, v)
+}
+
+// SetTimestamp enables (default) or disables the timestamp in front of every message.
+// It also drops the layout set by SetTimeFormat.
+func (l *Logger) SetTimestamp(v bool) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+	l.mu.Lock()
+	l.customTime = false
+	l.mu.Unlock()
+
+	l.setFlags(log.LstdFlags, v)
+}
+
+// SetLineWrap sets strings written before and after every line: prefix comes
+// before the timestamp, suffix after the message, right before the newline.
+// Both are empty by default.
+func (l *Logger) SetLineWrap(prefix, suffix string) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.linePrefix = prefix
+	l.suffix = suffix
+}
+
+// SetNewline sets the string terminating every line, "\n" by default, e.g.
+// "\r\n" for Windows-style lines, or "" for none when the writer frames the
+// messages itself. A trailing newline of the message text is dropped anyway.
+func (l *Logger) SetNewline(s string) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.newline = s
+}
+
+// SetEscapeNewlines enables or disables (default) writing the newlines and
+// carriage returns of the message text and fields as the literal \n and \r,
+// so that every message takes a single line, e.g. a multi-line error.
+// Hooks and syslog still get the message as is.
+func (l *Logger) SetEscapeNewlines(v bool) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.escapeNL = v
+}
+
+// newlineEscaper replaces the newlines and carriage returns, see
+// SetEscapeNewlines.
+var newlineEscaper = strings.NewReplacer("\n", `\n`, "\r", `\r`)
+
+// SetSkipEmpty enables or disables (default) dropping messages with an empty
+// text, such as Info() or Infof(""), rather than printing a bare prefix.
+// Messages with fields, e.g. l.With("k", 1).Info(), are still printed.
+func (l *Logger) SetSkipEmpty(v bool) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.skipEmpty = v
+}
+
+// truncated marks the message texts cut by SetMaxMessageLength.
+const truncated = "…(truncated)"
+
+// SetMaxMessageLength truncates the message texts longer than n bytes, for
+// collectors rejecting huge lines: the first n bytes are kept, short of an
+// incomplete UTF-8 character, followed by "…(truncated)". The fields and the
+// line ID (see SetLineID) are left whole. A value of 0 disables truncation (default).
+func (l *Logger) SetMaxMessageLength(n int) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.maxLen = n
+}
+
+// truncate cuts s as set by SetMaxMessageLength, requiring l.omu to be held.
+func (l *Logger) truncate(s string) string {
+	if l.maxLen <= 0 || len(s) <= l.maxLen {
+		return s
+	}
+	n := l.maxLen
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + truncated
+}
+
+// setFlags sets or clears the given flags, leaving the others untouched,
+// and applies the result to the output.
+func (l *Logger) setFlags(flags int, v bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if v {
+		l.flags |= flags
+	} else {
+		l.flags &^= flags
 	}
 
-	l.out.SetFlags(flags)
+	l.applyFlags()
+}
+
+// applyFlags sets the flags of the output header, requiring l.mu to be
+// held. With a formatter, the output only writes the lines.
+func (l *Logger) applyFlags() {
+	out := l.flags
+	if out&log.LstdFlags == 0 {
+		// Lmicroseconds alone would still print the time of day.
+		out &^= log.Lmicroseconds
+	}
+	if l.customTime {
+		// The timestamp comes first, the caller must follow it.
+		out &^= log.LstdFlags | log.Lmicroseconds | log.LUTC | log.Lshortfile | log.Llongfile
+	}
+	if l.formatter != nil {
+		out = 0
+	}
+	l.hdrFlags.Store(int64(out))
 }
 
 // SetLevel selects the minimum logging level to print.
+// The callbacks registered by OnLevelChange are called if the level changes.
 func (l *Logger) SetLevel(level Severity) {
-	l.level = level
+	old := Severity(l.level.Swap(int64(level)))
+	if old == level {
+		return
+	}
+
+	l.mu.Lock()
+	fns := l.onLevel
+	l.mu.Unlock()
+	for _, fn := range fns {
+		fn(old, level)
+	}
+}
+
+// OnLevelChange registers fn to be called by SetLevel whenever it changes
+// the level, with the previous and the new one; e.g. to log the change.
+// The callbacks are called in the order they were registered, with no lock
+// held, and are not inherited by the loggers derived from l.
+func (l *Logger) OnLevelChange(fn func(old, new Severity)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.onLevel = append(l.onLevel[:len(l.onLevel):len(l.onLevel)], fn)
+}
+
+// V reports whether verbosity depth n is enabled, in the manner of glog:
+// V(0) is LevelInfo, V(1) LevelDebug and V(2) LevelTrace, so that V(n) holds
+// as long as SetLevel has not raised the level above LevelInfo-n.
+// It is a single comparison, cheap enough to guard expensive arguments:
+//
+//	if l.V(2) {
+//		l.Trace(dump(state))
+//	}
+func (l *Logger) V(n int) bool {
+	return l.Level() <= LevelInfo-Severity(n)
 }
 
 // Level returns the log level currently set.
 func (l *Logger) Level() Severity {
-	return l.level
+	return Severity(l.level.Load())
 }
 
-// SetWriter sets the logger's output stream for messages.
+// SetWriter sets the logger's output stream for messages, replacing any
+// writer added by AddWriter.
 func (l *Logger) SetWriter(w io.Writer) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.setWriter(w)
+}
+
+// WithWriter returns a clone of l (see Clone) writing every message, Error
+// and Fatal ones included, to w; e.g. to capture the output of one operation
+// without disturbing the other users of l.
+func (l *Logger) WithWriter(w io.Writer) *Logger {
+	c := l.Clone()
+	c.out.SetOutput(w)
+	c.errOut = nil
+	c.levelOut = nil
+	return c
+}
+
+// setWriter is like SetWriter but requires l.omu to be held.
+func (l *Logger) setWriter(w io.Writer) {
+	if l.async != nil {
+		l.async.setTarget(w)
+		return
+	}
+	if l.pause != nil {
+		l.pause.w = w
+		return
+	}
 	l.out.SetOutput(w)
 }
 
 // Writer returns the output stream for the logger.
 func (l *Logger) Writer() io.Writer {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	return l.writer()
+}
+
+// SetErrorWriter sets the output stream for Error and Fatal messages,
+// which go to the main writer (see SetWriter) by default or if w is nil.
+// SetStderrMinLevel sends lower levels to it as well.
+func (l *Logger) SetErrorWriter(w io.Writer) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	switch {
+	case w == nil:
+		l.errOut = nil
+	case l.errOut == nil:
+		l.errOut = newOutput(w)
+	default:
+		l.errOut.SetOutput(w)
+	}
+	if l.pause != nil {
+		l.pause.ew = w
+		if l.errOut != nil {
+			l.errOut.SetOutput(pauseErr{l.pause})
+		}
+	}
+}
+
+// SetStderrMinLevel sets the minimum level of the messages going to the error
+// writer, LevelError by default, e.g. LevelWarning for Warning and Error
+// messages on os.Stderr and the others on os.Stdout:
+//
+//	l.SetErrorWriter(os.Stderr)
+//	l.SetStderrMinLevel(LevelWarning)
+//
+// Without an error writer, every message goes to the main writer; a level
+// writer (see SetLevelWriter) takes precedence.
+func (l *Logger) SetStderrMinLevel(min Severity) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.errMin = min
+}
+
+// ErrorWriter returns the output stream for Error and Fatal messages.
+func (l *Logger) ErrorWriter() io.Writer {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	return l.errorWriter()
+}
+
+// SetLevelWriter sets the output stream for the messages of the given level,
+// e.g. to send Debug and Info messages to os.Stdout, Warning ones to a file
+// and Error ones to os.Stderr. A level without a writer of its own, or with
+// a nil w, goes to the error writer (see SetErrorWriter) or the main one.
+// The level writers are neither paused nor asynchronous (see Pause and SetAsync).
+func (l *Logger) SetLevelWriter(level Severity, w io.Writer) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	outs := make(map[Severity]*output, len(l.levelOut)+1)
+	for k, o := range l.levelOut {
+		outs[k] = o
+	}
+	if w == nil {
+		delete(outs, level)
+	} else {
+		outs[level] = newOutput(w)
+	}
+	if len(outs) == 0 {
+		outs = nil
+	}
+	l.levelOut = outs
+}
+
+// errorWriter is like ErrorWriter but requires l.omu to be held.
+func (l *Logger) errorWriter() io.Writer {
+	switch {
+	case l.errOut == nil:
+		return l.writer()
+	case l.pause != nil:
+		return l.pause.ew
+	}
+	return l.errOut.Writer()
+}
+
+// writer is like Writer but requires l.omu to be held.
+func (l *Logger) writer() io.Writer {
+	if l.async != nil {
+		return l.async.target()
+	}
+	if l.pause != nil {
+		return l.pause.w
+	}
 	return l.out.Writer()
 }
 
+// Prefix returns the label printed in front of messages of the given level,
+// or an empty string for an unknown level.
+func (l *Logger) Prefix(level Severity) string {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	return l.prefix(level)
+}
+
+// SetPrefix sets the label printed in front of messages of the given level,
+// e.g. "WARNING " instead of "WARN> ", leaving the other levels and loggers
+// untouched. It applies to the default output; the structured formats, such
+// as JSONFormatter, get the level name from the label, without surrounding
+// spaces and trailing '>' or ':', e.g. "NOTICE" from "NOTICE> ".
+func (l *Logger) SetPrefix(level Severity, p string) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	// Copy on write, clones may share the map.
+	prefixes := make(map[Severity]string, len(prefix)+1)
+	for level := range prefix {
+		prefixes[level] = l.prefix(level)
+	}
+	prefixes[level] = p
+	l.prefixes = prefixes
+}
+
+// SetPrefixes sets the labels of the levels in prefixes, see SetPrefix.
+func (l *Logger) SetPrefixes(prefixes map[Severity]string) {
+	for level, p := range prefixes {
+		l.SetPrefix(level, p)
+	}
+}
+
+// levelName returns the name of level derived from the label set by
+// SetPrefix, if any. It requires l.omu to be held.
+func (l *Logger) levelName(level Severity) (string, bool) {
+	if l.prefixes == nil {
+		return "", false
+	}
+	p, ok := l.prefixes[level]
+	if !ok || p == prefix[level] {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(p), ">:"))
+	return name, name != ""
+}
+
+// Tag returns the tag printed in front of every message.
+func (l *Logger) Tag() string {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	return l.tag
+}
+
+// SetTag sets a tag printed in front of every message whatever its level,
+// after the timestamp and caller and before the level label, e.g.
+// "[payments] ". It is empty by default. It applies to the default output,
+// not to a custom Formatter.
+func (l *Logger) SetTag(tag string) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.tag = tag
+}
+
+// prefix is like Prefix but requires l.omu to be held.
+func (l *Logger) prefix(level Severity) string {
+	if l.prefixes != nil {
+		return l.prefixes[level]
+	}
+	return prefix[level]
+}
+
+// Output logs s at the given level on the standard output, see
+// Logger.Output.
+func Output(calldepth int, level Severity, s string) {
+	std.Output(calldepth+1, level, s)
+}
+
+// Output logs s at the given level, for helpers wrapping the logger, in the
+// manner of the Output method of the standard log package: calldepth is the
+// number of frames to skip when reporting the caller, 1 being the caller of
+// Output.
+// Log message is emitted only if the current logging level is equal or less than the level.
+func (l *Logger) Output(calldepth int, level Severity, s string) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(calldepth+1, 0, level, "", s, nil)
+}
+
+// output writes the message s prefixed by the level label.
+// It must be called directly by the logging methods for the call depth to hold.
+func (l *Logger) output(level Severity, s string) {
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(l.calldepth+1, 0, level, "", s, nil)
+}
+
+// emit is like output but requires l.omu to be held, and released by
+// l.unlock for the hooks to fire, calldepth being relative to emit as it is
+// to Output in the standard library. A pc other than zero is the caller to
+// report instead, see runtime.Callers.
+// id is the line ID, generated if empty and line IDs are enabled.
+// extra are fields of this message only, rendered after the logger fields
+// and replacing those with the same key.
+func (l *Logger) emit(calldepth int, pc uintptr, level Severity, id, s string, extra []Field) {
+	if s == "" && l.skipEmpty && len(l.fields) == 0 && len(extra) == 0 {
+		return
+	}
+	if sp := l.samplers[level]; sp != nil && !sp.allow(s) {
+		return
+	}
+	s = l.truncate(s)
+	if id == "" && l.lineID {
+		id = newLineID()
+	}
+	if id != "" {
+		s = "[" + id + "] " + s
+	}
+	base, fieldText := l.fields, l.fieldText
+	if len(extra) > 0 && len(base) > 0 {
+		if b := dropFields(base, extra); len(b) < len(base) {
+			base, fieldText = b, fieldsText(b)
+		}
+	}
+	var fields []Field // the logger and message fields, if transformed or capped
+	switch {
+	case l.fieldFn != nil:
+		fields = l.transformFields(base, extra)
+		fieldText = fieldsText(l.limitFields(fields))
+	case l.maxDepth.Load() > 0:
+		fields = append(base[:len(base):len(base)], extra...)
+		fieldText = fieldsText(l.limitFields(fields))
+	default:
+		fieldText += fieldsText(extra)
+	}
+	if l.recSinks != nil || l.ring != nil {
+		if fields == nil {
+			l.writeRecord(calldepth+1, pc, level, s, append(base[:len(base):len(base)], extra...))
+		} else {
+			l.writeRecord(calldepth+1, pc, level, s, fields)
+		}
+	}
+	if l.sys != nil || l.hooks != nil {
+		msg := l.redact(strings.TrimSuffix(s, "\n") + fieldText + resourceFields())
+		if l.hooks != nil {
+			l.firings = append(l.firings, firing{l.hooks, level, msg})
+		}
+		if l.sys != nil {
+			l.sys.WriteLevel(level, msg) // #nosec
+		}
+	}
+	out, w := l.out, l.writer
+	if o := l.levelOut[level]; o != nil {
+		out, w = o, o.Writer
+	} else if level >= l.errMin && l.errOut != nil {
+		out, w = l.errOut, l.errorWriter
+	}
+	first := l.firstLeft.Load() > 0 && l.firstLeft.Add(-1) >= 0
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if l.formatter != nil {
+		if fields == nil {
+			fields = append(base[:len(base):len(base)], extra...)
+		}
+		b := l.appendHeader(*buf, calldepth+1, pc)
+		line := strings.TrimSuffix(l.redact(l.format(calldepth+1, pc, level, s, fields, first)), "\n")
+		if l.escapeNL {
+			line = newlineEscaper.Replace(line)
+		}
+		b = append(b, line...)
+		b = append(b, l.newline...)
+		*buf = b
+		out.write(b) // #nosec
+		return
+	}
+	b, callerFlags := l.appendTimestamp(l.appendHeader(*buf, calldepth+1, pc))
+	if (first || callerFlags != 0 || l.callerAt(level)) && l.headerFlags()&(log.Lshortfile|log.Llongfile) == 0 {
+		b = append(b, caller(calldepth, pc, callerFlags&log.Llongfile != 0)...)
+		b = append(b, ": "...)
+	}
+	b = append(b, l.tag...)
+	if l.color == ColorNever {
+		b = append(b, l.prefix(level)...)
+	} else {
+		b = append(b, l.colorize(level, w())...)
+	}
+	body := len(b)
+	b = l.appendBody(b, s, fieldText)
+	if l.redactors != nil {
+		b = append(b[:body], l.redact(string(b[body:]))...)
+	}
+	if l.escapeNL {
+		b = append(b[:body], newlineEscaper.Replace(string(b[body:]))...)
+	}
+	b = append(b, l.suffix...)
+	b = append(b, l.newline...)
+	*buf = b
+	out.write(b) // #nosec
+}
+
+// appendTimestamp appends to b the timestamp rendered with the layout set by
+// SetTimeFormat followed by a space, and returns the caller flags (see
+// SetCaller), as both are then left out by the header of the output.
+// It requires l.omu to be held.
+func (l *Logger) appendTimestamp(b []byte) ([]byte, int) {
+	if !l.customTime {
+		return b, 0
+	}
+	l.mu.Lock()
+	flags := l.flags
+	l.mu.Unlock()
+
+	callerFlags := flags & (log.Lshortfile | log.Llongfile)
+	if l.timeFormat == "" {
+		return b, callerFlags
+	}
+	now := time.Now()
+	if flags&log.LUTC != 0 {
+		now = now.UTC()
+	}
+	return append(now.AppendFormat(b, l.timeFormat), ' '), callerFlags
+}
+
+// Enabled reports whether a message of the given level would be printed,
+// so that callers can skip building costly arguments.
+// No message is printed at LevelOff, or above, whatever the level set.
+func (l *Logger) Enabled(level Severity) bool {
+	return level < LevelOff && level >= l.Level()
+}
+
+// levelWriter is a destination of the messages aware of their level.
+type levelWriter interface {
+	WriteLevel(level Severity, msg string) error
+	Close() error
+}
+
+// callerAt reports whether messages of the given level get the caller
+// regardless of Verbose, see SetCallerMinLevel.
+func (l *Logger) callerAt(level Severity) bool {
+	min := Severity(l.callerMin.Load())
+	return min < LevelOff && level >= min
+}
+
+// caller returns the "file:line" of the function calldepth frames above
+// the one calling caller, or of pc if not zero, in the manner of
+// log.Lshortfile, or of log.Llongfile if long.
+func caller(calldepth int, pc uintptr, long bool) string {
+	return frameCaller(callerFrame(calldepth+1, pc)).location(long)
+}
+
+// callerFrame returns the frame of the function calldepth frames above the
+// one calling callerFrame, or of pc if not zero, with "???" as file if it
+// cannot be found.
+func callerFrame(calldepth int, pc uintptr) runtime.Frame {
+	pcs := [1]uintptr{pc}
+	if pc == 0 && runtime.Callers(calldepth+2, pcs[:]) == 0 {
+		return runtime.Frame{File: "???"}
+	}
+	f, _ := runtime.CallersFrames(pcs[:]).Next()
+	if f.File == "" {
+		f.File = "???"
+	}
+	return f
+}
+
+// frameFunc returns the function name of f qualified by its package name,
+// such as "log.New".
+func frameFunc(f runtime.Frame) string {
+	name := f.Function
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// sprintln formats using the default formats for its operands, in the manner
+// of fmt.Sprintln, without the trailing newline (Output already adds one).
+func sprintln(v ...interface{}) string {
+	s := fmt.Sprintln(v...)
+	return s[:len(s)-1]
+}
+
 // newStd is used to initializes the default logger.
 func newStd() *Logger {
 	v := New(LevelInfo)
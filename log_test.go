@@ -2,18 +2,33 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 const ts = `^[0-9]{4}/[0-9]{2}/[0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2}\.[0-9]{6} `
 
 var lp = [...]string{"INFO> ", "WARN> ", "ERROR> "}
 
+const (
+	dp = "DEBUG> "
+	tp = "TRACE> "
+)
+
 var tt = []struct {
 	name     string
 	f        func()
@@ -21,6 +36,37 @@ var tt = []struct {
 	prefix   string
 	want     string
 }{
+	{"Trace normal", func() { Trace("Ciao") }, LevelTrace, tp, "Ciao"},
+	{"Trace double number", func() { Trace(3, 7) }, LevelTrace, tp, "3 7"},
+	{"Trace level debug", func() { Trace("Ciao") }, LevelDebug, "", ""},
+	{"Trace level error", func() { Trace("Ciao") }, LevelError, "", ""},
+	{"Tracef normal", func() { Tracef("Ciao") }, LevelTrace, tp, "Ciao"},
+	{"Tracef format", func() { Tracef("fmt: %s %v", "ciao", 7) }, LevelTrace, tp, "fmt: ciao 7"},
+	{"Tracef level debug", func() { Tracef("Ciao") }, LevelDebug, "", ""},
+	{"Traceln normal", func() { Traceln("Ciao") }, LevelTrace, tp, "Ciao"},
+	{"Traceln double string", func() { Traceln("Ciao", "ciao") }, LevelTrace, tp, "Ciao ciao"},
+	{"Traceln level debug", func() { Traceln("Ciao") }, LevelDebug, "", ""},
+	{"Debug level trace", func() { Debug("Ciao") }, LevelTrace, dp, "Ciao"},
+	{"Debug normal", func() { Debug("Ciao") }, LevelDebug, dp, "Ciao"},
+	{"Debug double string", func() { Debug("Ciao", "ciao") }, LevelDebug, dp, "Ciaociao"},
+	{"Debug string number", func() { Debug("Ciao", 7) }, LevelDebug, dp, "Ciao7"},
+	{"Debug number string", func() { Debug(7, "Ciao") }, LevelDebug, dp, "7Ciao"},
+	{"Debug double number", func() { Debug(3, 7) }, LevelDebug, dp, "3 7"},
+	{"Debug level info", func() { Debug("Ciao") }, LevelInfo, "", ""},
+	{"Debug level warning", func() { Debug("Ciao") }, LevelWarning, "", ""},
+	{"Debug level error", func() { Debug("Ciao") }, LevelError, "", ""},
+	{"Debugf normal", func() { Debugf("Ciao") }, LevelDebug, dp, "Ciao"},
+	{"Debugf format", func() { Debugf("fmt: %s %v", "ciao", 7) }, LevelDebug, dp, "fmt: ciao 7"},
+	{"Debugf level info", func() { Debugf("Ciao") }, LevelInfo, "", ""},
+	{"Debugf level error", func() { Debugf("Ciao") }, LevelError, "", ""},
+	{"Debugln normal", func() { Debugln("Ciao") }, LevelDebug, dp, "Ciao"},
+	{"Debugln double string", func() { Debugln("Ciao", "ciao") }, LevelDebug, dp, "Ciao ciao"},
+	{"Debugln string number", func() { Debugln("Ciao", 7) }, LevelDebug, dp, "Ciao 7"},
+	{"Debugln number string", func() { Debugln(7, "Ciao") }, LevelDebug, dp, "7 Ciao"},
+	{"Debugln double number", func() { Debugln(3, 7) }, LevelDebug, dp, "3 7"},
+	{"Debugln level info", func() { Debugln("Ciao") }, LevelInfo, "", ""},
+	{"Debugln level error", func() { Debugln("Ciao") }, LevelError, "", ""},
+	{"Info level debug", func() { Info("Ciao") }, LevelDebug, lp[0], "Ciao"},
 	{"Info normal", func() { Info("Ciao") }, LevelInfo, lp[0], "Ciao"},
 	{"Info double string", func() { Info("Ciao", "ciao") }, LevelInfo, lp[0], "Ciaociao"},
 	{"Info string number", func() { Info("Ciao", 7) }, LevelInfo, lp[0], "Ciao7"},
@@ -75,6 +121,9 @@ var tt = []struct {
 	{"Errorln double number", func() { Errorln(3, 7) }, LevelInfo, lp[2], "3 7"},
 	{"Errorln level warning", func() { Errorln("Ciao") }, LevelWarning, lp[2], "Ciao"},
 	{"Errorln level error", func() { Errorln("Ciao") }, LevelError, lp[2], "Ciao"},
+	{"Error level above error", func() { Error("Ciao") }, LevelError + 1, "", ""},
+	{"Errorf level above error", func() { Errorf("Ciao") }, LevelError + 1, "", ""},
+	{"Errorln level above error", func() { Errorln("Ciao") }, LevelError + 1, "", ""},
 	{"Verbose", func() { Verbose(true); Info("Ciao") }, LevelInfo, "log_test.go:[0-9]+: " + lp[0], "Ciao"},
 	{"Verbose enabled and disabled", func() { Verbose(true); Verbose(false); Info("Ciao") }, LevelInfo, lp[0], "Ciao"},
 }
@@ -106,6 +155,16 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+// sleepWriter is a slow writer.
+type sleepWriter struct {
+	w io.Writer
+}
+
+func (s sleepWriter) Write(p []byte) (int, error) {
+	time.Sleep(50 * time.Millisecond)
+	return s.w.Write(p)
+}
+
 func TestFatals(t *testing.T) {
 	tt := []struct {
 		name     string
@@ -135,42 +194,579 @@ func TestFatals(t *testing.T) {
 
 	idx, err := strconv.Atoi(os.Getenv("FATAL_IDX"))
 	if err == nil {
+		if os.Getenv("FATAL_STDERR") != "" {
+			SetWriter(os.Stderr)
+		}
+		if os.Getenv("FATAL_ASYNC") != "" {
+			// Slow enough for the line to be lost unless Fatal flushes.
+			SetWriter(sleepWriter{os.Stdout})
+			SetAsync(10)
+		}
 		SetLevel(LevelError)
 		tt[idx].f()
 		return // just in case...
 	}
 
+	// Fatal output is captured from both streams, so it is checked whatever
+	// the stream the child writes to.
+	streams := []struct {
+		name string
+		env  string
+	}{
+		{"stdout", "FATAL_STDERR="},
+		{"stderr", "FATAL_STDERR=1"},
+		{"async", "FATAL_ASYNC=1"},
+	}
+
 	for i, tc := range tt {
-		t.Run(tc.name, func(t *testing.T) {
-			out := new(bytes.Buffer)
-			cmd := exec.Command(os.Args[0], "-test.run=TestFatals")
-			cmd.Env = append(os.Environ(), "FATAL_IDX="+strconv.Itoa(i))
-			cmd.Stdout = out
-			err = cmd.Run()
-			var e *exec.ExitError
-			if errors.As(err, &e) {
-				if ret := e.ExitCode(); ret != 1 {
-					t.Fatalf("wrong exit code: want 1, got %d", ret)
+		for _, stream := range streams {
+			t.Run(tc.name+" "+stream.name, func(t *testing.T) {
+				out := new(bytes.Buffer)
+				cmd := exec.Command(os.Args[0], "-test.run=TestFatals")
+				cmd.Env = append(os.Environ(), "FATAL_IDX="+strconv.Itoa(i), stream.env)
+				cmd.Stdout = out
+				cmd.Stderr = out
+				err = cmd.Run()
+				var e *exec.ExitError
+				if errors.As(err, &e) {
+					if ret := e.ExitCode(); ret != 1 {
+						t.Fatalf("wrong exit code: want 1, got %d", ret)
+					}
+
+					line := out.String()
+					if len(line) > 0 {
+						line = line[0 : len(line)-1]
+					}
+					var pattern string
+					if tc.want != "" {
+						pattern = ts + lp[2] + tc.want + "$"
+					}
+					matched, err := regexp.MatchString(pattern, line)
+					if err != nil {
+						t.Fatalf("unable to compile regex %q: %v", pattern, err)
+					}
+					if !matched {
+						t.Fatalf("mismatch! Pattern %q, got %q", pattern, line)
+					}
+					return
 				}
+				t.Fatalf("unexpected err value %v, want exit status 1", err)
+			})
+		}
+	}
+}
+
+func TestFatalPolicy(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{"Fatal", func() { Fatal("Ciao", 7) }, "Ciao7"},
+		{"Fatalf", func() { Fatalf("fmt: %s %v", "ciao", 7) }, "fmt: ciao 7"},
+		{"Fatalln", func() { Fatalln("Ciao", "ciao") }, "Ciao ciao"},
+	}
+
+	defer SetFatalPolicy(nil)
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			SetWriter(w)
+			SetLevel(LevelInfo)
+			var got string
+			SetFatalPolicy(func(msg string) bool {
+				got = msg
+				return false
+			})
+			tc.f() // returns instead of exiting
+
+			if got != tc.want {
+				t.Fatalf("policy got %q, want %q", got, tc.want)
+			}
+			pattern := ts + lp[2] + tc.want + "\n$"
+			if matched, _ := regexp.MatchString(pattern, w.String()); !matched {
+				t.Fatalf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestPanic(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		f     func()
+		want  string
+	}{
+		{"Panic", LevelInfo, func() { Panic("Ciao", 7) }, "Ciao7"},
+		{"Panicf", LevelInfo, func() { Panicf("fmt: %s %v", "ciao", 7) }, "fmt: ciao 7"},
+		{"Panicln", LevelInfo, func() { Panicln("Ciao", "ciao") }, "Ciao ciao"},
+		{"level off", LevelOff, func() { Panic("Ciao") }, "Ciao"},
+	}
 
-				line := out.String()
-				if len(line) > 0 {
-					line = line[0 : len(line)-1]
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			SetWriter(w)
+			SetLevel(tc.level)
+			defer SetLevel(LevelInfo)
+			defer func() {
+				if got := recover(); got != tc.want {
+					t.Errorf("panic value: want %q, got %#v", tc.want, got)
+				}
+				pattern := ts + lp[2] + tc.want + "\n$"
+				if tc.level == LevelOff {
+					pattern = "^$"
+				}
+				if matched, _ := regexp.MatchString(pattern, w.String()); !matched {
+					t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
 				}
-				var pattern string
-				if tc.want != "" {
-					pattern = ts + lp[2] + tc.want + "$"
+			}()
+			tc.f()
+			t.Error("no panic")
+		})
+	}
+}
+
+func TestSingleNewline(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func()
+	}{
+		{"Infoln", func() { Infoln("Ciao") }},
+		{"Infoln double string", func() { Infoln("Ciao", "ciao") }},
+		{"Infoln empty", func() { Infoln() }},
+		{"Warningln", func() { Warningln("Ciao") }},
+		{"Errorln", func() { Errorln("Ciao") }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			SetWriter(w)
+			SetLevel(LevelInfo)
+			tc.f()
+
+			if n := strings.Count(w.String(), "\n"); n != 1 {
+				t.Fatalf("want exactly one newline, got %d in %q", n, w.String())
+			}
+		})
+	}
+}
+
+func TestFlags(t *testing.T) {
+	const tsNoMicro = `^[0-9]{4}/[0-9]{2}/[0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2} `
+	const caller = "log_test.go:[0-9]+: "
+
+	tt := []struct {
+		name    string
+		f       func(l *Logger)
+		flags   int
+		pattern string
+	}{
+		{"default", func(l *Logger) {}, stdFlags, ts + lp[0]},
+		{"verbose", func(l *Logger) { l.Verbose(true) }, stdFlags | log.Lshortfile, ts + caller + lp[0]},
+		{"verbose then UTC", func(l *Logger) { l.Verbose(true); l.SetUTC(true) }, stdFlags | log.Lshortfile | log.LUTC, ts + caller + lp[0]},
+		{"UTC then verbose off", func(l *Logger) { l.SetUTC(true); l.Verbose(false) }, stdFlags | log.LUTC, ts + lp[0]},
+		{"no microseconds", func(l *Logger) { l.SetMicroseconds(false) }, log.LstdFlags, tsNoMicro + lp[0]},
+		{"no microseconds then verbose", func(l *Logger) { l.SetMicroseconds(false); l.Verbose(true) }, log.LstdFlags | log.Lshortfile, tsNoMicro + caller + lp[0]},
+		{"no timestamp", func(l *Logger) { l.SetTimestamp(false) }, 0, "^" + lp[0]},
+		{"no timestamp verbose", func(l *Logger) { l.SetTimestamp(false); l.Verbose(true) }, log.Lshortfile, "^" + caller + lp[0]},
+		{"timestamp restored", func(l *Logger) { l.SetTimestamp(false); l.SetTimestamp(true) }, stdFlags, ts + lp[0]},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			tc.f(l)
+			l.Info("Ciao")
+
+			if got := l.headerFlags(); got != tc.flags {
+				t.Errorf("flags mismatch: want %b, got %b", tc.flags, got)
+			}
+			pattern := tc.pattern + "Ciao\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestSetTimeFormat(t *testing.T) {
+	const rfc3339 = `^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(Z|[+-][0-9]{2}:[0-9]{2}) `
+	const caller = "log_test.go:[0-9]+: "
+
+	tt := []struct {
+		name    string
+		f       func(l *Logger)
+		pattern string
+	}{
+		{"RFC3339", func(l *Logger) { l.SetTimeFormat(time.RFC3339) }, rfc3339 + lp[0]},
+		{"custom", func(l *Logger) { l.SetTimeFormat("15h04") }, "^[0-9]{2}h[0-9]{2} " + lp[0]},
+		{"empty", func(l *Logger) { l.SetTimeFormat("") }, "^" + lp[0]},
+		{"verbose", func(l *Logger) { l.Verbose(true); l.SetTimeFormat(time.RFC3339) }, rfc3339 + caller + lp[0]},
+		{"then verbose", func(l *Logger) { l.SetTimeFormat(time.RFC3339); l.Verbose(true) }, rfc3339 + caller + lp[0]},
+		{"line wrap", func(l *Logger) { l.SetLineWrap(">> ", ""); l.SetTimeFormat(time.RFC3339) }, "^>> " + rfc3339[1:] + lp[0]},
+		{"no microseconds", func(l *Logger) { l.SetTimeFormat(time.RFC3339); l.SetMicroseconds(false) }, rfc3339 + lp[0]},
+		{"timestamp restored", func(l *Logger) { l.SetTimeFormat(""); l.SetTimestamp(true) }, ts + lp[0]},
+		{"timestamp disabled", func(l *Logger) { l.SetTimeFormat(time.RFC3339); l.SetTimestamp(false) }, "^" + lp[0]},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			tc.f(l)
+			l.Info("Ciao")
+
+			pattern := tc.pattern + "Ciao\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestSetUTC(t *testing.T) {
+	tt := []struct {
+		name   string
+		f      func(l *Logger)
+		layout string
+	}{
+		{"standard", func(l *Logger) {}, "2006/01/02 15:04:05.000000"},
+		{"time format", func(l *Logger) { l.SetTimeFormat(time.RFC3339Nano) }, time.RFC3339Nano},
+		{"time format first", func(l *Logger) { l.SetUTC(false); l.SetTimeFormat("2006-01-02 15:04:05.000"); l.SetUTC(true) }, "2006-01-02 15:04:05.000"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetUTC(true)
+			tc.f(l)
+			l.Info("Ciao")
+
+			i := strings.Index(w.String(), " "+lp[0])
+			if i < 0 {
+				t.Fatalf("no level prefix in %q", w.String())
+			}
+			// Parsed as UTC, a local timestamp is off by the zone offset.
+			got, err := time.Parse(tc.layout, w.String()[:i])
+			if err != nil {
+				t.Fatalf("bad timestamp: %v", err)
+			}
+			if d := time.Since(got); d < 0 || d > time.Minute {
+				t.Errorf("timestamp %v is %v away from %v", got, d, time.Now().UTC())
+			}
+		})
+	}
+}
+
+func TestSetCaller(t *testing.T) {
+	const short = "^log_test.go:[0-9]+: "
+	const long = "^/.+/log_test.go:[0-9]+: "
+
+	tt := []struct {
+		name    string
+		f       func(l *Logger)
+		pattern string
+	}{
+		{"none", func(l *Logger) { l.SetCaller(CallerNone) }, "^"},
+		{"short", func(l *Logger) { l.SetCaller(CallerShort) }, short},
+		{"long", func(l *Logger) { l.SetCaller(CallerLong) }, long},
+		{"long then short", func(l *Logger) { l.SetCaller(CallerLong); l.SetCaller(CallerShort) }, short},
+		{"long then verbose off", func(l *Logger) { l.SetCaller(CallerLong); l.Verbose(false) }, "^"},
+		{"verbose", func(l *Logger) { l.Verbose(true) }, short},
+		{"long time format", func(l *Logger) { l.SetTimeFormat(""); l.SetCaller(CallerLong) }, long},
+		{"min level time format", func(l *Logger) { l.SetTimeFormat(""); l.SetCallerMinLevel(LevelInfo) }, short},
+		{"long formatter", func(l *Logger) { l.SetFormatter(TextFormatter{}); l.SetCaller(CallerLong) }, long},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetTimestamp(false)
+			tc.f(l)
+			l.Info("Ciao")
+
+			pattern := tc.pattern + lp[0] + "Ciao\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+// infoHelper wraps Info, as a helper of the application would.
+func infoHelper(l *Logger, v ...interface{}) { l.Info(v...) }
+
+func TestWithWriter(t *testing.T) {
+	wrap := "^>> " + ts[1:]
+
+	tt := []struct {
+		name   string
+		f      func(parent, child *Logger)
+		parent string // pattern of the parent output, empty if none
+		child  string // pattern of the child output, empty if none
+	}{
+		{"independent", func(p, c *Logger) { p.Info("parent"); c.Info("child") }, wrap + lp[0] + "parent\n$", wrap + lp[0] + "child\n$"},
+		{"errors", func(p, c *Logger) { p.Error("parent"); c.Error("child") }, "", wrap + lp[2] + "child\n$"},
+		{"level", func(p, c *Logger) { c.SetLevel(LevelError); p.Info("parent"); c.Info("child") }, wrap + lp[0] + "parent\n$", ""},
+		{"writer", func(p, c *Logger) { p.SetWriter(io.Discard); p.Info("parent"); c.Info("child") }, "", wrap + lp[0] + "child\n$"},
+		{"settings", func(p, c *Logger) { c.SetTimestamp(false); p.Info("parent"); c.Info("child") }, wrap + lp[0] + "parent\n$", "^>> " + lp[0] + "child\n$"},
+		{"fields", func(p, c *Logger) { c.With("a", 1).Info("child") }, "", wrap + lp[0] + "child a=1\n$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			pw, cw := new(bytes.Buffer), new(bytes.Buffer)
+			parent := New(LevelInfo, WithWriter(pw), WithErrorWriter(io.Discard))
+			parent.SetLineWrap(">> ", "")
+			child := parent.WithWriter(cw)
+			tc.f(parent, child)
+
+			for _, o := range []struct{ name, pattern, got string }{
+				{"parent", tc.parent, pw.String()},
+				{"child", tc.child, cw.String()},
+			} {
+				pattern := o.pattern
+				if pattern == "" {
+					pattern = "^$"
 				}
-				matched, err := regexp.MatchString(pattern, line)
-				if err != nil {
-					t.Fatalf("unable to compile regex %q: %v", pattern, err)
+				if !regexp.MustCompile(pattern).MatchString(o.got) {
+					t.Errorf("%s mismatch! Pattern %q, got %q", o.name, pattern, o.got)
 				}
-				if !matched {
-					t.Fatalf("mismatch! Pattern %q, got %q", pattern, line)
+			}
+		})
+	}
+}
+
+func TestSetCallDepth(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetTimestamp(false)
+	l.Verbose(true)
+	if got := l.CallDepth(); got != 2 {
+		t.Errorf("default call depth: want 2, got %d", got)
+	}
+	if got := CallDepth(); got != 3 {
+		t.Errorf("standard logger call depth: want 3, got %d", got)
+	}
+
+	infoHelper(l, "Ciao")
+	l.SetCallDepth(l.CallDepth() + 1)
+	_, _, line, _ := runtime.Caller(0)
+	infoHelper(l, "Ciao")
+
+	want := callerOf(infoHelper) + lp[0] + "Ciao\n" +
+		"log_test.go:" + strconv.Itoa(line+1) + ": " + lp[0] + "Ciao\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+}
+
+// callerMark in a test prefix stands for the location of the test function.
+const callerMark = "@"
+
+// callerOf returns the "file:line: " caller prefix for the one-line function f.
+func callerOf(f interface{}) string {
+	file, line := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).FileLine(reflect.ValueOf(f).Pointer())
+	return filepath.Base(file) + ":" + strconv.Itoa(line) + ": "
+}
+
+func TestCallerMinLevel(t *testing.T) {
+	const caller = callerMark
+
+	tt := []struct {
+		name    string
+		f       func(l *Logger)
+		min     Severity
+		verbose bool
+		prefix  string
+	}{
+		{"info below min", func(l *Logger) { l.Info("Ciao") }, LevelWarning, false, lp[0]},
+		{"warning at min", func(l *Logger) { l.Warning("Ciao") }, LevelWarning, false, caller + lp[1]},
+		{"errorf above min", func(l *Logger) { l.Errorf("Ciao") }, LevelWarning, false, caller + lp[2]},
+		{"errorln disabled", func(l *Logger) { l.Errorln("Ciao") }, LevelOff, false, lp[2]},
+		{"verbose not repeated", func(l *Logger) { l.Error("Ciao") }, LevelInfo, true, caller + lp[2]},
+		{"package level", func(*Logger) { SetCallerMinLevel(LevelError); Error("Ciao"); SetCallerMinLevel(LevelOff) }, LevelOff, false, caller + lp[2]},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			SetWriter(w)
+			l.Verbose(tc.verbose)
+			l.SetCallerMinLevel(tc.min)
+			tc.f(l)
+
+			prefix := strings.Replace(tc.prefix, callerMark, callerOf(tc.f), 1)
+			pattern := ts + prefix + "Ciao\\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestVerboseForFirst(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelWarning)
+	l.SetWriter(w)
+	l.VerboseForFirst(2)
+	f := func() { l.Info("skip"); l.Warning("one"); l.Error("two"); l.Error("three") }
+	f()
+
+	want := []string{callerOf(f) + lp[1] + "one", callerOf(f) + lp[2] + "two", lp[2] + "three"}
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want %d lines, got %q", len(want), lines)
+	}
+	for i, line := range lines {
+		pattern := ts + regexp.QuoteMeta(want[i]) + "$"
+		if !regexp.MustCompile(pattern).MatchString(line) {
+			t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+		}
+	}
+}
+
+func TestSkipEmpty(t *testing.T) {
+	tt := []struct {
+		name string
+		skip bool
+		f    func(l *Logger)
+		want string
+	}{
+		{"Info kept", false, func(l *Logger) { l.Info() }, lp[0]},
+		{"Infof kept", false, func(l *Logger) { l.Infof("") }, lp[0]},
+		{"Info skipped", true, func(l *Logger) { l.Info() }, ""},
+		{"Infof skipped", true, func(l *Logger) { l.Infof("") }, ""},
+		{"Infoln skipped", true, func(l *Logger) { l.Infoln() }, ""},
+		{"Error empty string skipped", true, func(l *Logger) { l.Error("") }, ""},
+		{"Info not empty", true, func(l *Logger) { l.Info("Ciao") }, lp[0] + "Ciao"},
+		{"Warningf blank", true, func(l *Logger) { l.Warningf(" ") }, lp[1] + " "},
+		{"With fields kept", true, func(l *Logger) { l.With("k", 1).Info() }, lp[0] + " k=1"},
+		{"Infow fields kept", true, func(l *Logger) { l.Infow("", "user", "bob") }, lp[0] + " user=bob"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetSkipEmpty(tc.skip)
+			tc.f(l)
+
+			if tc.want == "" {
+				if w.Len() != 0 {
+					t.Fatalf("want no output, got %q", w.String())
 				}
 				return
 			}
-			t.Fatalf("unexpected err value %v, want exit status 1", err)
+			pattern := ts + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestLineWrap(t *testing.T) {
+	tt := []struct {
+		name   string
+		prefix string
+		suffix string
+		f      func(l *Logger)
+		want   string
+	}{
+		{"none", "", "", func(l *Logger) { l.Info("Ciao") }, ts + lp[0] + "Ciao\n$"},
+		{"prefix", ">>> ", "", func(l *Logger) { l.Info("Ciao") }, "^>>> " + ts[1:] + lp[0] + "Ciao\n$"},
+		{"suffix", "", " <<<", func(l *Logger) { l.Warning("Ciao") }, ts + lp[1] + "Ciao <<<\n$"},
+		{"both", "[", "]", func(l *Logger) { l.Errorln("Ciao") }, `^\[` + ts[1:] + lp[2] + `Ciao\]\n$`},
+		{"trailing newline", "", "]", func(l *Logger) { l.Info("Ciao\n") }, ts + lp[0] + "Ciao]\n$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetLineWrap(tc.prefix, tc.suffix)
+			tc.f(l)
+
+			if !regexp.MustCompile(tc.want).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", tc.want, w.String())
+			}
+		})
+	}
+}
+
+func TestSetNewline(t *testing.T) {
+	tt := []struct {
+		name    string
+		newline string
+		f       func(l *Logger)
+		want    string
+	}{
+		{"default", "\n", func(l *Logger) { l.Info("Ciao") }, ts + lp[0] + "Ciao\n$"},
+		{"CRLF", "\r\n", func(l *Logger) { l.Info("Ciao") }, ts + lp[0] + "Ciao\r\n$"},
+		{"none", "", func(l *Logger) { l.Info("Ciao") }, ts + lp[0] + "Ciao$"},
+		{"none trailing newline", "", func(l *Logger) { l.Infoln("Ciao") }, ts + lp[0] + "Ciao$"},
+		{"after suffix", "\r\n", func(l *Logger) { l.SetLineWrap("", " <<<"); l.Warning("Ciao") }, ts + lp[1] + "Ciao <<<\r\n$"},
+		{"formatter", "\r\n", func(l *Logger) { l.SetFormatter(JSONFormatter{}); l.Info("Ciao") }, `"msg":"Ciao"}\r\n$`},
+		{"formatter none", "", func(l *Logger) { l.SetFormatter(JSONFormatter{}); l.Info("Ciao") }, `"msg":"Ciao"}$`},
+		{"two lines", "", func(l *Logger) { l.Info("a"); l.Info("b") }, ts + lp[0] + "a" + ts[1:] + lp[0] + "b$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetNewline(tc.newline)
+			tc.f(l)
+
+			if !regexp.MustCompile(tc.want).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", tc.want, w.String())
+			}
+		})
+	}
+}
+
+func TestSetEscapeNewlines(t *testing.T) {
+	tt := []struct {
+		name   string
+		escape bool
+		f      func(l *Logger)
+		want   string
+	}{
+		{"disabled", false, func(l *Logger) { l.Info("a\nb") }, ts + lp[0] + "a\nb\n$"},
+		{"multi-line", true, func(l *Logger) { l.Info("a\nb\r\nc") }, ts + lp[0] + `a\\nb\\r\\nc` + "\n$"},
+		{"trailing newline", true, func(l *Logger) { l.Infoln("a\nb") }, ts + lp[0] + `a\\nb` + "\n$"},
+		{"error", true, func(l *Logger) { l.Error(errors.New("a\nb")) }, ts + lp[2] + `a\\nb` + "\n$"},
+		{"field", true, func(l *Logger) { l.Infow("Ciao", "trace", "a\nb") }, ts + lp[0] + `Ciao trace=a\\nb` + "\n$"},
+		{"suffix kept", true, func(l *Logger) { l.SetLineWrap("", "\n--"); l.Info("a\nb") }, ts + lp[0] + `a\\nb` + "\n--\n$"},
+		{"formatter", true, func(l *Logger) { l.SetFormatter(TextFormatter{}); l.Info("a\nb") }, ts + lp[0] + `a\\nb` + "\n$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetEscapeNewlines(tc.escape)
+			tc.f(l)
+
+			if !regexp.MustCompile(tc.want).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", tc.want, w.String())
+			}
 		})
 	}
 }
@@ -196,13 +792,591 @@ func TestWriter(t *testing.T) {
 	}
 }
 
+func TestErrorWriter(t *testing.T) {
+	w, ew := new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	if l.ErrorWriter() != w {
+		t.Error("ErrorWriter: want the main writer by default")
+	}
+
+	l.SetErrorWriter(ew)
+	if l.ErrorWriter() != ew {
+		t.Error("mismatch on io.Writer parameter after SET/GET cycle")
+	}
+	l.SetFatalPolicy(func(string) bool { return false })
+	l.Info("info")
+	l.Warning("warning")
+	l.Error("error")
+	l.Fatal("fatal")
+	l.SetErrorWriter(nil)
+	l.Error("back")
+
+	check := func(name string, w *bytes.Buffer, want []string) {
+		lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+		if len(lines) != len(want) {
+			t.Fatalf("%s: want %d lines, got %q", name, len(want), lines)
+		}
+		for i, line := range lines {
+			pattern := ts + want[i] + "$"
+			if !regexp.MustCompile(pattern).MatchString(line) {
+				t.Errorf("%s: mismatch! Pattern %q, got %q", name, pattern, line)
+			}
+		}
+	}
+	check("main", w, []string{lp[0] + "info", lp[1] + "warning", lp[2] + "back"})
+	check("error", ew, []string{lp[2] + "error", lp[2] + "fatal"})
+}
+
+func TestStderrMinLevel(t *testing.T) {
+	tt := []struct {
+		name      string
+		min       Severity
+		errWriter bool
+		wantOut   string
+		wantErr   string
+	}{
+		{"default", LevelError, true, lp[0] + "i\n" + lp[1] + "w\n", lp[2] + "e\n"},
+		{"warning", LevelWarning, true, lp[0] + "i\n", lp[1] + "w\n" + lp[2] + "e\n"},
+		{"all", LevelTrace, true, "", lp[0] + "i\n" + lp[1] + "w\n" + lp[2] + "e\n"},
+		{"none", LevelOff, true, lp[0] + "i\n" + lp[1] + "w\n" + lp[2] + "e\n", ""},
+		{"no error writer", LevelWarning, false, lp[0] + "i\n" + lp[1] + "w\n" + lp[2] + "e\n", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, ew := new(bytes.Buffer), new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetTimestamp(false)
+			if tc.errWriter {
+				l.SetErrorWriter(ew)
+			}
+			l.SetStderrMinLevel(tc.min)
+			c := l.With()
+			c.Info("i")
+			c.Warning("w")
+			c.Error("e")
+
+			if w.String() != tc.wantOut {
+				t.Errorf("main: want %q, got %q", tc.wantOut, w.String())
+			}
+			if ew.String() != tc.wantErr {
+				t.Errorf("error: want %q, got %q", tc.wantErr, ew.String())
+			}
+		})
+	}
+}
+
+func TestSetLevelWriter(t *testing.T) {
+	w, ww, ew, dw := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelTrace, WithWriter(w))
+	l.SetLevelWriter(LevelDebug, dw)
+	l.SetLevelWriter(LevelWarning, ww)
+	l.SetLevelWriter(LevelError, ew)
+	l.SetErrorWriter(new(bytes.Buffer)) // overridden by the level writer
+	l.SetLineWrap(">> ", "")
+	l.Verbose(true)
+	l.SetFatalPolicy(func(string) bool { return false })
+	l.Trace("trace")
+	l.Debug("debug")
+	l.Info("info")
+	l.Warning("warning")
+	l.Error("error")
+	l.Fatal("fatal")
+	l.SetLevelWriter(LevelDebug, nil)
+	l.Debug("back")
+
+	check := func(name string, w *bytes.Buffer, want []string) {
+		lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+		if len(lines) != len(want) {
+			t.Fatalf("%s: want %d lines, got %q", name, len(want), lines)
+		}
+		for i, line := range lines {
+			pattern := "^>> " + ts[1:] + "log_test.go:[0-9]+: " + want[i] + "$"
+			if !regexp.MustCompile(pattern).MatchString(line) {
+				t.Errorf("%s: mismatch! Pattern %q, got %q", name, pattern, line)
+			}
+		}
+	}
+	check("main", w, []string{tp + "trace", lp[0] + "info", dp + "back"})
+	check("debug", dw, []string{dp + "debug"})
+	check("warning", ww, []string{lp[1] + "warning"})
+	check("error", ew, []string{lp[2] + "error", lp[2] + "fatal"})
+}
+
+func TestOnLevelChange(t *testing.T) {
+	l := New(LevelInfo)
+	var got []string
+	l.OnLevelChange(func(old, new Severity) { got = append(got, "first "+old.String()+">"+new.String()) })
+	l.OnLevelChange(func(old, new Severity) { got = append(got, "second "+old.String()+">"+new.String()) })
+	l.SetLevel(LevelInfo) // no change
+	l.SetLevel(LevelError)
+	l.SetLevel(LevelError) // no change
+	l.SetLevel(LevelDebug)
+	l.With("a", 1).SetLevel(LevelTrace) // not inherited
+
+	want := []string{"first INFO>ERROR", "second INFO>ERROR", "first ERROR>DEBUG", "second ERROR>DEBUG"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mismatch! Want %q, got %q", want, got)
+	}
+}
+
+func TestSetMaxMessageLength(t *testing.T) {
+	tt := []struct {
+		name string
+		max  int
+		f    func(l *Logger)
+		want string
+	}{
+		{"disabled", 0, func(l *Logger) { l.Info(strings.Repeat("a", 100)) }, strings.Repeat("a", 100)},
+		{"short", 10, func(l *Logger) { l.Info("Ciao") }, "Ciao"},
+		{"exact", 4, func(l *Logger) { l.Info("Ciao") }, "Ciao"},
+		{"ASCII", 10, func(l *Logger) { l.Info(strings.Repeat("a", 1<<20)) }, "aaaaaaaaaa…(truncated)"},
+		{"multibyte boundary", 2, func(l *Logger) { l.Info("héllo") }, "h…(truncated)"},
+		{"multibyte after", 3, func(l *Logger) { l.Info("héllo") }, "hé…(truncated)"},
+		{"multibyte first", 1, func(l *Logger) { l.Info("€uro") }, "…(truncated)"},
+		{"fields kept", 4, func(l *Logger) { l.With("a", 1).Infow("Ciao ciao", "b", 2) }, "Ciao…(truncated) a=1 b=2"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetMaxMessageLength(tc.max)
+			tc.f(l)
+
+			pattern := ts + regexp.QuoteMeta(lp[0]+tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %.200q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestLevels(t *testing.T) {
+	want := []string{tp, dp, lp[0], lp[1], lp[2]}
+	levels := Levels()
+	if len(levels) != len(want) {
+		t.Fatalf("want %d levels, got %d", len(want), len(levels))
+	}
+	for i, level := range levels {
+		if i > 0 && level <= levels[i-1] {
+			t.Errorf("levels not in ascending order: %v", levels)
+		}
+		if Prefix(level) != want[i] {
+			t.Errorf("level %v: want prefix %q, got %q", level, want[i], Prefix(level))
+		}
+	}
+}
+
+func TestLevelOff(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelOff)
+	defer SetLevel(LevelInfo)
+	SetFatalPolicy(func(string) bool { return false })
+	defer SetFatalPolicy(nil)
+
+	for _, tc := range tt {
+		tc.f()
+	}
+	Fatal("Ciao")
+	Fatalf("Ciao")
+	Fatalln("Ciao")
+	Verbose(false)
+	if w.Len() > 0 {
+		t.Fatalf("want no output, got %q", w.String())
+	}
+}
+
+func TestLevelOffMessages(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+	}{
+		{"Table", func(l *Logger) { l.Table(LevelOff, map[string]string{"k": "v"}) }},
+		{"Diff", func(l *Logger) { l.Diff(LevelOff, "x", 1, 2) }},
+		{"JSON", func(l *Logger) { l.JSON(LevelOff, "x", 1) }},
+		{"LogfID", func(l *Logger) { l.LogfID(LevelOff, "Ciao") }},
+		{"Sprintf", func(l *Logger) { l.Sprintf(LevelOff, "Ciao") }},
+		{"Print", func(l *Logger) { l.SetDefaultLevel(LevelOff); l.Print("Ciao") }},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, level := range []Severity{LevelTrace, LevelOff} {
+				w := new(bytes.Buffer)
+				l := New(level, WithWriter(w))
+				tc.f(l)
+
+				if w.Len() > 0 {
+					t.Errorf("level %v: want no output, got %q", level, w.String())
+				}
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tt := []struct {
+		level Severity
+		want  string
+	}{
+		{LevelTrace, "TRACE"},
+		{LevelDebug, "DEBUG"},
+		{LevelInfo, "INFO"},
+		{LevelWarning, "WARN"},
+		{LevelError, "ERROR"},
+		{LevelOff, "OFF"},
+		{LevelOff + 1, "Severity(4)"},
+		{-7, "Severity(-7)"},
+	}
+
+	for _, tc := range tt {
+		if got := tc.level.String(); got != tc.want {
+			t.Errorf("want %q, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, level := range append(Levels(), LevelOff) {
+		text, err := level.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", level, err)
+		}
+		var got Severity
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("%v: unexpected error: %v", level, err)
+		}
+		if got != level {
+			t.Errorf("round trip: want %v, got %v", level, got)
+		}
+	}
+
+	var cfg struct{ Level Severity }
+	if err := json.Unmarshal([]byte(`{"Level":"warn"}`), &cfg); err != nil || cfg.Level != LevelWarning {
+		t.Errorf("json: want WARN, nil, got %v, %v", cfg.Level, err)
+	}
+	if b, _ := json.Marshal(cfg); string(b) != `{"Level":"WARN"}` {
+		t.Errorf("json: want WARN, got %s", b)
+	}
+
+	unknown := LevelOff + 3
+	text, err := unknown.MarshalText()
+	if err != nil || string(text) != "Severity(6)" {
+		t.Errorf("unknown level: want Severity(6), nil, got %q, %v", text, err)
+	}
+	if err := new(Severity).UnmarshalText([]byte("loud")); err == nil {
+		t.Error("want error for unknown name, got nil")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tt := []struct {
+		in   string
+		want Severity
+	}{
+		{"trace", LevelTrace},
+		{"TRACE", LevelTrace},
+		{"debug", LevelDebug},
+		{"Debug", LevelDebug},
+		{"info", LevelInfo},
+		{"INFO", LevelInfo},
+		{"warn", LevelWarning},
+		{"WARN", LevelWarning},
+		{"warning", LevelWarning},
+		{"Warning", LevelWarning},
+		{"error", LevelError},
+		{"Error", LevelError},
+		{"off", LevelOff},
+		{"OFF", LevelOff},
+		{" info\n", LevelInfo},
+	}
+
+	for _, tc := range tt {
+		got, err := ParseLevel(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("%q: want %v, nil, got %v, %v", tc.in, tc.want, got, err)
+		}
+	}
+	for _, level := range append(Levels(), LevelOff) {
+		if got, err := ParseLevel(level.String()); err != nil || got != level {
+			t.Errorf("round trip: want %v, nil, got %v, %v", level, got, err)
+		}
+	}
+	for _, in := range []string{"", "loud", "inf", "Severity(1)"} {
+		if _, err := ParseLevel(in); err == nil {
+			t.Errorf("%q: want error, got nil", in)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	levels := Levels()
+	for _, level := range levels {
+		SetLevel(level)
+		for _, msg := range levels {
+			want := msg >= level
+			if got := Enabled(msg); got != want {
+				t.Errorf("level %v: Enabled(%v) want %v, got %v", level, msg, want, got)
+			}
+		}
+	}
+	SetLevel(LevelError + 1)
+	if Enabled(LevelError) {
+		t.Error("level above error: Enabled(LevelError) want false, got true")
+	}
+	SetLevel(LevelInfo)
+}
+
+func TestV(t *testing.T) {
+	tt := []struct {
+		level Severity
+		want  [4]bool // V(0) to V(3)
+	}{
+		{LevelTrace, [4]bool{true, true, true, false}},
+		{LevelDebug, [4]bool{true, true, false, false}},
+		{LevelInfo, [4]bool{true, false, false, false}},
+		{LevelWarning, [4]bool{false, false, false, false}},
+		{LevelError, [4]bool{false, false, false, false}},
+	}
+
+	for _, tc := range tt {
+		SetLevel(tc.level)
+		for n, want := range tc.want {
+			if got := V(n); got != want {
+				t.Errorf("level %v: V(%d) want %v, got %v", tc.level, n, want, got)
+			}
+		}
+	}
+	SetLevel(LevelWarning)
+	if !V(-1) {
+		t.Error("level warning: V(-1) want true, got false")
+	}
+	SetLevel(LevelInfo)
+}
+
+func TestPrefix(t *testing.T) {
+	for level, want := range lp {
+		if got := Prefix(Severity(level)); got != want {
+			t.Errorf("Prefix(%d): want %q, got %q", level, want, got)
+		}
+	}
+	if got := Prefix(LevelDebug); got != dp {
+		t.Errorf("Prefix(LevelDebug): want %q, got %q", dp, got)
+	}
+	if got := Prefix(Severity(len(lp))); got != "" {
+		t.Errorf("unknown level: want empty prefix, got %q", got)
+	}
+}
+
+func TestSetPrefix(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetPrefix(LevelWarning, "WARNING ")
+	l.SetPrefix(LevelError, "[payments] ERROR> ")
+	other := New(LevelInfo)
+
+	if got := l.Prefix(LevelWarning); got != "WARNING " {
+		t.Errorf("Prefix(LevelWarning): want %q, got %q", "WARNING ", got)
+	}
+	if got := other.Prefix(LevelWarning); got != lp[1] {
+		t.Errorf("other logger: want %q, got %q", lp[1], got)
+	}
+	if got := New(LevelInfo).Prefix(LevelError); got != lp[2] {
+		t.Errorf("new logger: want %q, got %q", lp[2], got)
+	}
+
+	l.Info("one")
+	l.Warning("two")
+	l.Error("three")
+	want := []string{lp[0] + "one", "WARNING two", "\\[payments\\] ERROR> three"}
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want %d lines, got %q", len(want), lines)
+	}
+	for i, line := range lines {
+		pattern := ts + want[i] + "$"
+		if !regexp.MustCompile(pattern).MatchString(line) {
+			t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+		}
+	}
+}
+
+func TestPrefixLevelName(t *testing.T) {
+	tt := []struct {
+		name     string
+		prefixes map[Severity]string
+		want     string
+	}{
+		{"default", nil, `{"level":"warn","msg":"Ciao"}` + "\n"},
+		{"notice", map[Severity]string{LevelWarning: "NOTICE> "}, `{"level":"notice","msg":"Ciao"}` + "\n"},
+		{"warning", map[Severity]string{LevelWarning: " Warning: ", LevelError: "E "}, `{"level":"warning","msg":"Ciao"}` + "\n"},
+		{"same as default", map[Severity]string{LevelWarning: lp[1]}, `{"level":"warn","msg":"Ciao"}` + "\n"},
+		{"empty", map[Severity]string{LevelWarning: ""}, `{"level":"warn","msg":"Ciao"}` + "\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetTimestamp(false)
+			l.SetFormatter(JSONFormatter{})
+			l.SetPrefixes(tc.prefixes)
+			l.With().Warnw("Ciao", "level", 1)
+
+			want := strings.TrimSuffix(tc.want, "}\n") + `,"fields.level":1}` + "\n"
+			if w.String() != want {
+				t.Errorf("mismatch! Want %q, got %q", want, w.String())
+			}
+		})
+	}
+}
+
+func TestPrefixLevelNamePerLogger(t *testing.T) {
+	w := new(bytes.Buffer)
+	notice := New(LevelInfo, WithWriter(w))
+	notice.SetPrefix(LevelWarning, "NOTICE> ")
+	caution := New(LevelInfo, WithWriter(w))
+	caution.SetPrefix(LevelWarning, "CAUTION ")
+	plain := New(LevelInfo, WithWriter(w))
+	for _, l := range []*Logger{notice, caution, plain} {
+		l.SetTimestamp(false)
+		l.SetFormatter(JSONFormatter{})
+		l.Warning("Ciao")
+	}
+	notice.SetFormatter(nil)
+	notice.Warning("Ciao")
+	for _, l := range []*Logger{notice, caution, plain} {
+		l.SetFormatter(TextFormatter{})
+		l.Warning("Ciao")
+	}
+
+	want := `{"level":"notice","msg":"Ciao"}` + "\n" +
+		`{"level":"caution","msg":"Ciao"}` + "\n" +
+		`{"level":"warn","msg":"Ciao"}` + "\n" +
+		"NOTICE> Ciao\n" +
+		"NOTICE> Ciao\n" +
+		"CAUTION> Ciao\n" +
+		lp[1] + "Ciao\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+	if LevelWarning.String() != "WARN" {
+		t.Errorf("global name changed: %q", LevelWarning.String())
+	}
+}
+
+func TestTag(t *testing.T) {
+	tt := []struct {
+		name string
+		tag  string
+		f    func()
+		want string
+	}{
+		{"empty", "", func() { Info("Ciao") }, regexp.QuoteMeta(lp[0] + "Ciao")},
+		{"info", "[payments] ", func() { Info("Ciao") }, regexp.QuoteMeta("[payments] " + lp[0] + "Ciao")},
+		{"warning", "[payments] ", func() { Warning("Ciao") }, regexp.QuoteMeta("[payments] " + lp[1] + "Ciao")},
+		{"error", "[payments] ", func() { Error("Ciao") }, regexp.QuoteMeta("[payments] " + lp[2] + "Ciao")},
+		{"verbose", "[payments] ", func() { Verbose(true); Info("Ciao"); Verbose(false) }, "log_test.go:[0-9]+: " + regexp.QuoteMeta("[payments] "+lp[0]+"Ciao")},
+		{"caller min level", "[payments] ", func() { SetCallerMinLevel(LevelInfo); Info("Ciao"); SetCallerMinLevel(LevelOff) }, "log_test.go:[0-9]+: " + regexp.QuoteMeta("[payments] "+lp[0]+"Ciao")},
+	}
+
+	defer SetTag("")
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			SetWriter(w)
+			SetLevel(LevelInfo)
+			SetTag(tc.tag)
+			if got := Tag(); got != tc.tag {
+				t.Errorf("Tag: want %q, got %q", tc.tag, got)
+			}
+			tc.f()
+
+			pattern := ts + tc.want + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	l := New(LevelInfo)
+	l.SetWriter(SyncWriter(io.Discard))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Infof("Ciao %d", j)
+				l.With("j", j).Error("Ciao")
+				l.Enabled(LevelDebug)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.SetLevel(Levels()[j%len(Levels())])
+				l.SetCallerMinLevel(LevelWarning)
+				l.Verbose(j%2 == i%2)
+				_ = l.Level()
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.SetWriter(io.Discard)
+				l.SetFatalPolicy(nil)
+				l.SetFormatter(TextFormatter{})
+				l.SetFormatter(nil)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func BenchmarkInfo(b *testing.B) {
 	const msg = "Ciao"
 	var buf bytes.Buffer
 	l := New(LevelInfo)
 	l.SetWriter(&buf)
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		buf.Reset()
 		l.Info(msg)
 	}
 }
+
+func BenchmarkInfof(b *testing.B) {
+	var buf bytes.Buffer
+	l := New(LevelInfo)
+	l.SetWriter(&buf)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		l.Infof("Ciao %d", i)
+	}
+}
+
+func TestOutputCallDepth(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+	helper := func(s string) {
+		l.Output(2, LevelWarning, s)
+	}
+	_, _, line, _ := runtime.Caller(0)
+	helper("Ciao")
+	l.Output(1, LevelDebug, "disabled")
+	l.Output(1, LevelInfo, "direct")
+
+	pattern := ts + fmt.Sprintf("log_test.go:%d: ", line+1) + regexp.QuoteMeta(lp[1]+"Ciao") + "\n" +
+		`[0-9/]{10} [0-9:.]{15} ` + fmt.Sprintf("log_test.go:%d: ", line+3) + regexp.QuoteMeta(lp[0]+"direct") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
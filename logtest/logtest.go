@@ -0,0 +1,110 @@
+// Package logtest provides helpers to assert on the output of the package
+// level logger in tests, and loggers writing to the test log.
+package logtest
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dpmik/log"
+)
+
+// ExpectSilent runs fn and fails the test if it logs any Warning or Error
+// message through the package level logger.
+func ExpectSilent(t testing.TB, fn func()) {
+	t.Helper()
+
+	for _, e := range capture(fn) {
+		if e.level >= log.LevelWarning {
+			t.Errorf("unexpected log output: %v", e)
+		}
+	}
+}
+
+// ExpectLevel runs fn and fails the test unless it logs at least one message
+// of the given level through the package level logger.
+func ExpectLevel(t testing.TB, level log.Severity, fn func()) {
+	t.Helper()
+
+	entries := capture(fn)
+	for _, e := range entries {
+		if e.level == level {
+			return
+		}
+	}
+	t.Errorf("no %v message logged, got %v", level, entries)
+}
+
+// entry is a captured message.
+type entry struct {
+	level log.Severity
+	msg   string
+}
+
+// String returns the level and the message, as in "WARN Ciao".
+func (e entry) String() string {
+	return e.level.String() + " " + e.msg
+}
+
+// captureHook records the messages of every level.
+type captureHook struct {
+	mu      sync.Mutex
+	entries []entry
+}
+
+func (h *captureHook) Fire(level log.Severity, msg string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry{level, msg})
+}
+
+func (h *captureHook) Levels() []log.Severity {
+	return log.Levels()
+}
+
+// capture runs fn with the package level logger at the lowest level and
+// returns the logged messages.
+// The messages are captured by a hook as they are logged, whatever the
+// format, the prefixes and the writer they are routed to, so that the lines
+// printed to the error and level writers or drained later by an async
+// writer are seen too. The output to the main writer is discarded.
+// The previous writer and level are restored afterward.
+func capture(fn func()) []entry {
+	w, level := log.Writer(), log.Level()
+	h := new(captureHook)
+	defer func() {
+		log.RemoveHook(h)
+		log.SetWriter(w)
+		log.SetLevel(level)
+	}()
+
+	log.SetWriter(io.Discard)
+	log.SetLevel(log.Levels()[0])
+	log.AddHook(h)
+	fn()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.entries
+}
+
+// NewTestLogger returns a logger at LevelDebug writing every line with tb.Log,
+// so that the lines show along the running test and only if it fails or
+// runs verbose. The logger must not be used once the test is complete.
+func NewTestLogger(tb testing.TB) *log.Logger {
+	return log.New(log.LevelDebug, log.WithWriter(tbWriter{tb}))
+}
+
+// tbWriter is a writer logging every line with the Log method of tb.
+type tbWriter struct {
+	tb testing.TB
+}
+
+// Write logs p, without the trailing newline.
+func (w tbWriter) Write(p []byte) (int, error) {
+	w.tb.Helper()
+	w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
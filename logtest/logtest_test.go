@@ -0,0 +1,150 @@
+package logtest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/dpmik/log"
+)
+
+// fakeTB records failures and logs instead of failing the running test.
+type fakeTB struct {
+	testing.TB
+	errors []string
+	logs   []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, v ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, v...))
+}
+
+func (f *fakeTB) Log(v ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprint(v...))
+}
+
+func TestExpectSilent(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func()
+		fail bool
+	}{
+		{"nothing", func() {}, false},
+		{"info", func() { log.Info("Ciao") }, false},
+		{"warning", func() { log.Warning("Ciao") }, true},
+		{"error", func() { log.Error("Ciao") }, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := new(fakeTB)
+			ExpectSilent(f, tc.f)
+			if failed := len(f.errors) > 0; failed != tc.fail {
+				t.Fatalf("want failure %v, got %v (%q)", tc.fail, failed, f.errors)
+			}
+		})
+	}
+}
+
+func TestExpectLevel(t *testing.T) {
+	tt := []struct {
+		name  string
+		level log.Severity
+		f     func()
+		fail  bool
+	}{
+		{"error logged", log.LevelError, func() { log.Info("Ciao"); log.Error("Ciao") }, false},
+		{"error missing", log.LevelError, func() { log.Warning("Ciao") }, true},
+		{"nothing logged", log.LevelInfo, func() {}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := new(fakeTB)
+			ExpectLevel(f, tc.level, tc.f)
+			if failed := len(f.errors) > 0; failed != tc.fail {
+				t.Fatalf("want failure %v, got %v (%q)", tc.fail, failed, f.errors)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	log.SetLevel(log.LevelError)
+	ExpectSilent(t, func() { log.Info("Ciao") })
+
+	if log.Writer() != os.Stdout {
+		t.Errorf("writer not restored")
+	}
+	if log.Level() != log.LevelError {
+		t.Errorf("level not restored: want %v, got %v", log.LevelError, log.Level())
+	}
+}
+
+func TestNewTestLogger(t *testing.T) {
+	f := new(fakeTB)
+	l := NewTestLogger(f)
+	l.SetTimestamp(false)
+	l.Trace("Ciao")
+	l.Debug("Ciao")
+	l.Infof("Ciao %d", 7)
+	l.Errorln("Ciao")
+
+	want := []string{log.Prefix(log.LevelDebug) + "Ciao", log.Prefix(log.LevelInfo) + "Ciao 7", log.Prefix(log.LevelError) + "Ciao"}
+	if !reflect.DeepEqual(f.logs, want) {
+		t.Errorf("mismatch! Want %q, got %q", want, f.logs)
+	}
+}
+
+func TestCaptureRoutes(t *testing.T) {
+	tt := []struct {
+		name  string
+		setup func() (undo func())
+		f     func()
+		level log.Severity
+		fail  bool
+	}{
+		{"error writer", func() func() {
+			log.SetErrorWriter(new(bytes.Buffer))
+			return func() { log.SetErrorWriter(nil) }
+		}, func() { log.Error("Ciao") }, log.LevelError, false},
+		{"level writer", func() func() {
+			log.SetLevelWriter(log.LevelWarning, new(bytes.Buffer))
+			return func() { log.SetLevelWriter(log.LevelWarning, nil) }
+		}, func() { log.Warning("Ciao") }, log.LevelWarning, false},
+		{"async", func() func() {
+			log.SetAsync(16)
+			return func() { log.SetAsync(0) }
+		}, func() { log.Warning("Ciao") }, log.LevelWarning, false},
+		{"JSON", func() func() {
+			log.SetFormatter(log.JSONFormatter{})
+			return func() { log.SetFormatter(nil) }
+		}, func() { log.Error("Ciao") }, log.LevelError, false},
+		{"custom prefix", func() func() {
+			p := log.Prefix(log.LevelError)
+			log.SetPrefix(log.LevelError, "E ")
+			return func() { log.SetPrefix(log.LevelError, p) }
+		}, func() { log.Error("Ciao") }, log.LevelError, false},
+		{"colors", func() func() {
+			log.SetColor(log.ColorAlways)
+			return func() { log.SetColor(log.ColorNever) }
+		}, func() { log.Error("Ciao") }, log.LevelError, false},
+		{"level in message", func() func() { return func() {} }, func() { log.Info(log.Prefix(log.LevelError) + "Ciao") }, log.LevelError, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.setup()()
+
+			f := new(fakeTB)
+			ExpectLevel(f, tc.level, tc.f)
+			if failed := len(f.errors) > 0; failed != tc.fail {
+				t.Fatalf("want failure %v, got %v (%q)", tc.fail, failed, f.errors)
+			}
+		})
+	}
+}
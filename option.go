@@ -0,0 +1,38 @@
+package log
+
+import "io"
+
+// Option configures a Logger built by New.
+type Option func(l *Logger)
+
+// WithWriter sets the output stream of the logger, see Logger.SetWriter.
+func WithWriter(w io.Writer) Option {
+	return func(l *Logger) { l.SetWriter(w) }
+}
+
+// WithErrorWriter sets the output stream of Error and Fatal messages,
+// see Logger.SetErrorWriter.
+func WithErrorWriter(w io.Writer) Option {
+	return func(l *Logger) { l.SetErrorWriter(w) }
+}
+
+// WithLevelWriters sets the output stream of the messages of each level in
+// ws, the other levels going to the error or the main writer,
+// see Logger.SetLevelWriter.
+func WithLevelWriters(ws map[Severity]io.Writer) Option {
+	return func(l *Logger) {
+		for level, w := range ws {
+			l.SetLevelWriter(level, w)
+		}
+	}
+}
+
+// WithVerbose adds file and line number to the messages, see Logger.Verbose.
+func WithVerbose(v bool) Option {
+	return func(l *Logger) { l.Verbose(v) }
+}
+
+// WithTimeFormat sets the layout of the timestamp, see Logger.SetTimeFormat.
+func WithTimeFormat(layout string) Option {
+	return func(l *Logger) { l.SetTimeFormat(layout) }
+}
@@ -0,0 +1,92 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	w, ew := new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelInfo,
+		WithWriter(w),
+		WithErrorWriter(ew),
+		WithVerbose(true),
+		WithTimeFormat(time.RFC3339),
+	)
+	l.Info("Ciao")
+	l.Error("Ciao")
+
+	const rfc3339 = `^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(Z|[+-][0-9]{2}:[0-9]{2}) `
+	const caller = "option_test.go:[0-9]+: "
+	tt := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"writer", w.String(), rfc3339 + caller + regexp.QuoteMeta(lp[0]+"Ciao") + "\n$"},
+		{"error writer", ew.String(), rfc3339 + caller + regexp.QuoteMeta(lp[2]+"Ciao") + "\n$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !regexp.MustCompile(tc.want).MatchString(tc.got) {
+				t.Errorf("mismatch! Pattern %q, got %q", tc.want, tc.got)
+			}
+		})
+	}
+}
+
+func TestOptionsOrder(t *testing.T) {
+	tt := []struct {
+		name    string
+		opts    []Option
+		pattern string
+	}{
+		{"none", nil, ts + lp[0]},
+		{"verbose off", []Option{WithVerbose(true), WithVerbose(false)}, ts + lp[0]},
+		{"no timestamp", []Option{WithTimeFormat("")}, "^" + lp[0]},
+		{"no timestamp verbose", []Option{WithTimeFormat(""), WithVerbose(true)}, "^option_test.go:[0-9]+: " + lp[0]},
+		{"writer replaced", []Option{WithWriter(new(bytes.Buffer))}, ts + lp[0]},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, append(tc.opts, WithWriter(w))...)
+			l.Info("Ciao")
+
+			pattern := tc.pattern + "Ciao\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestWithLevelWriters(t *testing.T) {
+	w, ww, ew := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelInfo,
+		WithWriter(w),
+		WithLevelWriters(map[Severity]io.Writer{LevelWarning: ww, LevelError: ew}),
+		WithTimeFormat(""),
+	)
+	l.Info("i")
+	l.Warning("w")
+	l.Error("e")
+
+	for name, tc := range map[string]struct {
+		got  *bytes.Buffer
+		want string
+	}{
+		"default": {w, lp[0] + "i\n"},
+		"warning": {ww, lp[1] + "w\n"},
+		"error":   {ew, lp[2] + "e\n"},
+	} {
+		if tc.got.String() != tc.want {
+			t.Errorf("%s: want %q, got %q", name, tc.want, tc.got.String())
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package log
+
+import (
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// output is a destination of the lines of a logger, shared by the loggers
+// derived from it (see With). Its methods require the output lock of the
+// logger (see Logger.omu) to be held, which the derived loggers share too.
+type output struct {
+	w io.Writer
+}
+
+// newOutput returns an output writing to w.
+func newOutput(w io.Writer) *output {
+	return &output{w: w}
+}
+
+// Writer returns the output stream.
+func (o *output) Writer() io.Writer {
+	return o.w
+}
+
+// SetOutput sets the output stream.
+func (o *output) SetOutput(w io.Writer) {
+	o.w = w
+}
+
+// write writes the line p to the output stream.
+func (o *output) write(p []byte) error {
+	_, err := writeSink(o.w, p)
+	return err
+}
+
+// appendHeader appends to b the line prefix (see SetLineWrap), the
+// timestamp and the caller as selected by the header flags, in the manner of
+// log.Logger. It requires l.omu to be held.
+// calldepth and pc locate the caller as for caller, counted from appendHeader.
+func (l *Logger) appendHeader(b []byte, calldepth int, pc uintptr) []byte {
+	flags := l.headerFlags()
+	if flags&log.Lmsgprefix == 0 {
+		b = append(b, l.linePrefix...)
+	}
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		t := time.Now()
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+		if flags&log.Ldate != 0 {
+			year, month, day := t.Date()
+			b = appendInt(b, year, 4)
+			b = append(b, '/')
+			b = appendInt(b, int(month), 2)
+			b = append(b, '/')
+			b = appendInt(b, day, 2)
+			b = append(b, ' ')
+		}
+		if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+			hour, min, sec := t.Clock()
+			b = appendInt(b, hour, 2)
+			b = append(b, ':')
+			b = appendInt(b, min, 2)
+			b = append(b, ':')
+			b = appendInt(b, sec, 2)
+			if flags&log.Lmicroseconds != 0 {
+				b = append(b, '.')
+				b = appendInt(b, t.Nanosecond()/1e3, 6)
+			}
+			b = append(b, ' ')
+		}
+	}
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		b = append(b, caller(calldepth, pc, flags&log.Lshortfile == 0)...)
+		b = append(b, ": "...)
+	}
+	if flags&log.Lmsgprefix != 0 {
+		b = append(b, l.linePrefix...)
+	}
+	return b
+}
+
+// headerFlags returns the log.Logger flags of the line header, as set by
+// applyFlags.
+func (l *Logger) headerFlags() int {
+	return int(l.hdrFlags.Load())
+}
+
+// appendInt appends the decimal i, zero-padded to wid digits.
+func appendInt(b []byte, i int, wid int) []byte {
+	var d [20]byte
+	n := len(d) - 1
+	for i >= 10 || wid > 1 {
+		wid--
+		d[n] = byte('0' + i%10)
+		i /= 10
+		n--
+	}
+	d[n] = byte('0' + i)
+	return append(b, d[n:]...)
+}
+
+// bufPool holds the buffers lines are rendered into.
+var bufPool = sync.Pool{New: func() interface{} {
+	b := make([]byte, 0, 256)
+	return &b
+}}
+
+// maxPooled is the capacity above which a buffer is not pooled again,
+// so that a huge line does not pin its memory.
+const maxPooled = 64 << 10
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *[]byte {
+	return bufPool.Get().(*[]byte)
+}
+
+// putBuffer returns b to the pool.
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooled {
+		return
+	}
+	*b = (*b)[:0]
+	bufPool.Put(b)
+}
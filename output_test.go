@@ -0,0 +1,87 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"testing"
+)
+
+// sameLines compares lines written at the same time by two loggers, masking
+// the digits if they have a timestamp or a caller, which may differ.
+func sameLines(t *testing.T, flags int, got, want string) {
+	t.Helper()
+	if flags&(log.Ldate|log.Ltime|log.Lshortfile|log.Llongfile) != 0 {
+		digits := regexp.MustCompile(`[0-9]`)
+		got, want = digits.ReplaceAllString(got, "0"), digits.ReplaceAllString(want, "0")
+	}
+	if got != want {
+		t.Errorf("mismatch! Want %q, got %q", want, got)
+	}
+}
+
+func TestOutputHeader(t *testing.T) {
+	tt := []struct {
+		name  string
+		flags int
+	}{
+		{"none", 0},
+		{"date", log.Ldate},
+		{"time", log.Ltime},
+		{"standard", stdFlags},
+		{"UTC", stdFlags | log.LUTC},
+		{"short file", log.Lshortfile},
+		{"long file", log.Llongfile},
+		{"both files", log.Lshortfile | log.Llongfile},
+		{"message prefix", stdFlags | log.Lshortfile | log.Lmsgprefix},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, s := range []string{"Ciao", "Ciao\n", "", "\n"} {
+				got, want := new(bytes.Buffer), new(bytes.Buffer)
+				l, std := New(LevelInfo, WithWriter(got)), log.New(want, ">> ", tc.flags)
+				l.SetLineWrap(">> ", "")
+				l.hdrFlags.Store(int64(tc.flags))
+				l.Info(s)
+				std.Output(1, lp[0]+s) // #nosec
+
+				sameLines(t, tc.flags, got.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestOutputLevels(t *testing.T) {
+	tt := []struct {
+		name  string
+		f     func(l *Logger)
+		flags int
+	}{
+		{"default", func(l *Logger) {}, stdFlags},
+		{"verbose", func(l *Logger) { l.Verbose(true) }, stdFlags | log.Lshortfile},
+		{"long", func(l *Logger) { l.SetCaller(CallerLong) }, stdFlags | log.Llongfile},
+		{"UTC", func(l *Logger) { l.SetUTC(true) }, stdFlags | log.LUTC},
+		{"no timestamp", func(l *Logger) { l.SetTimestamp(false) }, 0},
+		{"line wrap", func(l *Logger) { l.SetLineWrap(">> ", "") }, stdFlags},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, level := range Levels() {
+				got, want := new(bytes.Buffer), new(bytes.Buffer)
+				l := New(LevelTrace, WithWriter(got))
+				tc.f(l)
+				std := log.New(want, l.linePrefix, tc.flags)
+				logf := map[Severity]func(format string, v ...interface{}){
+					LevelTrace: l.Tracef, LevelDebug: l.Debugf, LevelInfo: l.Infof,
+					LevelWarning: l.Warningf, LevelError: l.Errorf,
+				}[level]
+				logf("Ciao %d", 7)
+				std.Output(1, prefix[level]+"Ciao 7") // #nosec
+
+				sameLines(t, tc.flags, got.String(), want.String())
+			}
+		})
+	}
+}
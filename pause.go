@@ -0,0 +1,110 @@
+package log
+
+import "io"
+
+// defaultPauseMax is the default number of lines kept while paused.
+const defaultPauseMax = 1000
+
+// Pause pauses the standard logger output, returning the function resuming it.
+func Pause() (resume func()) {
+	return std.Pause()
+}
+
+// SetPauseBuffer sets how many lines the standard logger keeps while paused.
+func SetPauseBuffer(n int) {
+	std.SetPauseBuffer(n)
+}
+
+// Pause stops the logger from writing to its writer until the returned
+// function is called, e.g. while holding a lock the writer also needs.
+// Lines logged in the meantime are kept, with their original timestamp, and
+// written in order on resume; once the pause buffer (see SetPauseBuffer) is
+// full, further lines are dropped.
+// Pausing an already paused logger has no effect, the returned function
+// does nothing and only the first pause's resume restarts the output.
+func (l *Logger) Pause() (resume func()) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	if l.pause != nil {
+		return func() {}
+	}
+	b := &pauseBuffer{w: l.out.Writer(), max: l.pauseMax}
+	l.pause = b
+	l.out.SetOutput(b)
+	if l.errOut != nil {
+		b.ew = l.errOut.Writer()
+		l.errOut.SetOutput(pauseErr{b})
+	}
+
+	var done bool
+	return func() {
+		l.omu.Lock()
+		defer l.omu.Unlock()
+
+		if done {
+			return
+		}
+		done = true
+		for _, line := range b.lines {
+			w := b.w
+			if line.err && b.ew != nil {
+				w = b.ew
+			}
+			writeSink(w, line.p) // #nosec
+		}
+		l.out.SetOutput(b.w)
+		if l.errOut != nil {
+			l.errOut.SetOutput(b.ew)
+		}
+		l.pause = nil
+	}
+}
+
+// SetPauseBuffer sets how many lines are kept while the logger is paused,
+// 1000 by default. With n less than or equal to zero, every line logged
+// while paused is dropped. It applies from the next Pause.
+func (l *Logger) SetPauseBuffer(n int) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.pauseMax = n
+}
+
+// pauseBuffer keeps up to max lines written while the logger is paused.
+type pauseBuffer struct {
+	w     io.Writer // writer restored on resume
+	ew    io.Writer // error writer restored on resume, if any
+	max   int
+	lines []pausedLine
+}
+
+// pausedLine is a line kept while paused.
+type pausedLine struct {
+	p   []byte
+	err bool // for the error writer
+}
+
+// Write keeps a copy of p, or drops it if the buffer is full.
+// It is called with the logger's output lock held.
+func (b *pauseBuffer) Write(p []byte) (int, error) {
+	return b.add(p, false)
+}
+
+// add keeps a copy of p, or drops it if the buffer is full.
+func (b *pauseBuffer) add(p []byte, err bool) (int, error) {
+	if len(b.lines) < b.max {
+		b.lines = append(b.lines, pausedLine{p: append([]byte(nil), p...), err: err})
+	}
+	return len(p), nil
+}
+
+// pauseErr is the pause buffer as seen by the error writer.
+type pauseErr struct {
+	b *pauseBuffer
+}
+
+// Write keeps a copy of p for the error writer.
+func (e pauseErr) Write(p []byte) (int, error) {
+	return e.b.add(p, true)
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPause(t *testing.T) {
+	tt := []struct {
+		name string
+		max  int
+		want []string
+	}{
+		{"buffered", defaultPauseMax, []string{"before", "one", "two", "three", "after"}},
+		{"overflow", 2, []string{"before", "one", "two", "after"}},
+		{"drop", 0, []string{"before", "after"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			l.SetPauseBuffer(tc.max)
+
+			l.Info("before")
+			resume := l.Pause()
+			l.Info("one")
+			l.Warning("two")
+			l.Error("three")
+			if got := strings.Count(w.String(), "\n"); got != 1 {
+				t.Fatalf("output written while paused: %q", w.String())
+			}
+			resume()
+			l.Info("after")
+
+			lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+			if len(lines) != len(tc.want) {
+				t.Fatalf("want %d lines, got %q", len(tc.want), lines)
+			}
+			for i, line := range lines {
+				pattern := ts + "[A-Z]+> " + tc.want[i] + "$"
+				if !regexp.MustCompile(pattern).MatchString(line) {
+					t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+				}
+			}
+		})
+	}
+}
+
+func TestPauseWriter(t *testing.T) {
+	w1, w2 := new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w1)
+
+	resume := l.Pause()
+	if l.Writer() != w1 {
+		t.Error("Writer while paused: want the logger writer, got the pause buffer")
+	}
+	l.Info("Ciao")
+	l.SetWriter(w2)
+	if l.Writer() != w2 {
+		t.Error("SetWriter while paused not reflected by Writer")
+	}
+
+	nested := l.Pause()
+	nested()
+	l.Info("ciao")
+	if w1.Len() != 0 || w2.Len() != 0 {
+		t.Fatalf("nested resume restarted the output: %q, %q", w1.String(), w2.String())
+	}
+
+	resume()
+	resume()
+	if w1.Len() != 0 {
+		t.Errorf("output written to the replaced writer: %q", w1.String())
+	}
+	if got := strings.Count(w2.String(), "\n"); got != 2 {
+		t.Errorf("want 2 lines on the new writer, got %q", w2.String())
+	}
+}
+
+func TestPauseErrorWriter(t *testing.T) {
+	w, ew := new(bytes.Buffer), new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetErrorWriter(ew)
+
+	resume := l.Pause()
+	l.Info("one")
+	l.Error("two")
+	if w.Len() != 0 || ew.Len() != 0 {
+		t.Fatalf("output written while paused: %q, %q", w.String(), ew.String())
+	}
+	if l.ErrorWriter() != ew {
+		t.Error("ErrorWriter while paused: want the error writer, got the pause buffer")
+	}
+	resume()
+
+	if !strings.HasSuffix(w.String(), lp[0]+"one\n") || strings.Count(w.String(), "\n") != 1 {
+		t.Errorf("main writer: want one, got %q", w.String())
+	}
+	if !strings.HasSuffix(ew.String(), lp[2]+"two\n") || strings.Count(ew.String(), "\n") != 1 {
+		t.Errorf("error writer: want two, got %q", ew.String())
+	}
+}
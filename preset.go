@@ -0,0 +1,54 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[string]func(*Logger){
+		"dev": func(l *Logger) {
+			l.SetFormatter(nil)
+			l.SetColor(ColorAuto)
+			l.SetLevel(LevelInfo)
+			l.Verbose(true)
+		},
+		"prod": func(l *Logger) {
+			l.SetFormatter(JSONFormatter{})
+			l.SetColor(ColorNever)
+			l.SetLevel(LevelWarning)
+			l.Verbose(false)
+		},
+	}
+)
+
+// RegisterPreset registers under name a function configuring a Logger,
+// replacing any preset previously registered with the same name.
+// The built-in presets are "dev", verbose text colored on a terminal at
+// LevelInfo, and "prod", JSON (see JSONFormatter) at LevelWarning.
+func RegisterPreset(name string, apply func(*Logger)) {
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+
+	presets[name] = apply
+}
+
+// UsePreset configures the standard logger with the preset registered under name.
+func UsePreset(name string) error {
+	return std.UsePreset(name)
+}
+
+// UsePreset configures the logger with the preset registered under name.
+// An error is returned if no such preset exists.
+func (l *Logger) UsePreset(name string) error {
+	presetsMu.RLock()
+	apply, ok := presets[name]
+	presetsMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("log: unknown preset %q", name)
+	}
+	apply(l)
+	return nil
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"log"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	RegisterPreset("quiet", func(l *Logger) { l.SetLevel(LevelError) })
+
+	tt := []struct {
+		name      string
+		level     Severity
+		verbose   bool
+		color     ColorMode
+		formatter Formatter
+	}{
+		{"dev", LevelInfo, true, ColorAuto, nil},
+		{"prod", LevelWarning, false, ColorNever, JSONFormatter{}},
+		{"quiet", LevelError, false, ColorNever, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New(LevelError)
+			if err := l.UsePreset(tc.name); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if l.Level() != tc.level {
+				t.Errorf("level: want %v, got %v", tc.level, l.Level())
+			}
+			if verbose := l.headerFlags()&log.Lshortfile != 0; verbose != tc.verbose {
+				t.Errorf("verbose: want %v, got %v", tc.verbose, verbose)
+			}
+			if l.color != tc.color {
+				t.Errorf("color: want %v, got %v", tc.color, l.color)
+			}
+			if l.formatter != tc.formatter {
+				t.Errorf("formatter: want %T, got %T", tc.formatter, l.formatter)
+			}
+		})
+	}
+}
+
+func TestUnknownPreset(t *testing.T) {
+	l := New(LevelError)
+	if err := l.UsePreset("missing"); err == nil {
+		t.Fatal("want error for unknown preset, got nil")
+	}
+	if l.Level() != LevelError {
+		t.Errorf("logger modified by unknown preset")
+	}
+}
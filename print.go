@@ -0,0 +1,122 @@
+package log
+
+import "fmt"
+
+// Print logs a message of the default level on the standard output,
+// see Logger.Print.
+// Arguments are handled in the manner of fmt.Print.
+func Print(v ...interface{}) {
+	std.Print(v...)
+}
+
+// Printf logs a message of the default level on the standard output,
+// see Logger.Printf.
+// Arguments are handled in the manner of fmt.Printf.
+func Printf(format string, v ...interface{}) {
+	std.Printf(format, v...)
+}
+
+// Println logs a message of the default level on the standard output,
+// see Logger.Println.
+// Arguments are handled in the manner of fmt.Println.
+func Println(v ...interface{}) {
+	std.Println(v...)
+}
+
+// Sprintf logs a message of the given level on the standard output and
+// returns it, see Logger.Sprintf.
+func Sprintf(level Severity, format string, v ...interface{}) string {
+	return std.Sprintf(level, format, v...)
+}
+
+// LogString logs a message on the standard output at the level named
+// levelStr, see Logger.LogString.
+func LogString(levelStr string, v ...interface{}) error {
+	return std.LogString(levelStr, v...)
+}
+
+// SetDefaultLevel sets the level of the standard logger Print messages,
+// see Logger.SetDefaultLevel.
+func SetDefaultLevel(level Severity) {
+	std.SetDefaultLevel(level)
+}
+
+// SetDefaultLevel sets the level of the messages logged by Print, Printf
+// and Println, LevelInfo by default.
+func (l *Logger) SetDefaultLevel(level Severity) {
+	l.defLevel.Store(int64(level))
+}
+
+// DefaultLevel returns the level set by SetDefaultLevel.
+func (l *Logger) DefaultLevel() Severity {
+	return Severity(l.defLevel.Load())
+}
+
+// Print logs a message of the default level (see SetDefaultLevel), easing
+// the migration from the standard log package.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than the default level.
+func (l *Logger) Print(v ...interface{}) {
+	level := l.DefaultLevel()
+	if !l.Enabled(level) {
+		return
+	}
+	l.output(level, fmt.Sprint(v...))
+}
+
+// Printf logs a message of the default level (see SetDefaultLevel).
+// Arguments are handled in the manner of fmt.Printf.
+// Log message is emitted only if the current logging level is equal or less than the default level.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	level := l.DefaultLevel()
+	if !l.Enabled(level) {
+		return
+	}
+	l.output(level, fmt.Sprintf(format, v...))
+}
+
+// Println logs a message of the default level (see SetDefaultLevel).
+// Arguments are handled in the manner of fmt.Println.
+// Log message is emitted only if the current logging level is equal or less than the default level.
+func (l *Logger) Println(v ...interface{}) {
+	level := l.DefaultLevel()
+	if !l.Enabled(level) {
+		return
+	}
+	l.output(level, sprintln(v...))
+}
+
+// Sprintf logs a message of the given level and returns it as printed after
+// the timestamp and the caller, i.e. with the level label and the fields but
+// without line ID, colors and line suffix, e.g. to echo it in a response.
+// It is redacted as the printed message, see AddRedactor.
+// The message is returned even if its level is disabled.
+// Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Sprintf(level Severity, format string, v ...interface{}) string {
+	s := fmt.Sprintf(format, v...)
+
+	l.omu.Lock()
+	defer l.unlock()
+
+	if l.Enabled(level) {
+		l.emit(l.calldepth, 0, level, "", s, nil)
+	}
+	return l.tag + l.prefix(level) + l.redact(string(l.appendBody(nil, s, l.fieldText)))
+}
+
+// LogString logs a message at the level named levelStr, parsed by
+// ParseLevel, e.g. to replay events carrying their own severity.
+// For an unknown level name, the message is logged at the default level
+// (see SetDefaultLevel) and the ParseLevel error returned.
+// Arguments are handled in the manner of fmt.Print.
+// Log message is emitted only if the current logging level is equal or less than the level.
+func (l *Logger) LogString(levelStr string, v ...interface{}) error {
+	level, err := ParseLevel(levelStr)
+	if err != nil {
+		level = l.DefaultLevel()
+	}
+	if l.Enabled(level) {
+		l.output(level, fmt.Sprint(v...))
+	}
+	return err
+}
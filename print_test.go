@@ -0,0 +1,137 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		def   Severity
+		f     func()
+		want  string
+	}{
+		{"Print", LevelInfo, LevelInfo, func() { Print("Ciao", 7) }, lp[0] + "Ciao7\n"},
+		{"Printf", LevelInfo, LevelInfo, func() { Printf("fmt: %s %v", "ciao", 7) }, lp[0] + "fmt: ciao 7\n"},
+		{"Println", LevelInfo, LevelInfo, func() { Println("Ciao", "ciao") }, lp[0] + "Ciao ciao\n"},
+		{"like Info disabled", LevelWarning, LevelInfo, func() { Print("Ciao") }, ""},
+		{"default warning", LevelWarning, LevelWarning, func() { Print("Ciao") }, lp[1] + "Ciao\n"},
+		{"default error", LevelInfo, LevelError, func() { Printf("Ciao") }, lp[2] + "Ciao\n"},
+		{"default debug disabled", LevelInfo, LevelDebug, func() { Println("Ciao") }, ""},
+	}
+
+	defer SetDefaultLevel(LevelInfo)
+	defer SetLevel(LevelInfo)
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			SetWriter(w)
+			SetLevel(tc.level)
+			SetDefaultLevel(tc.def)
+			tc.f()
+
+			pattern := "^$"
+			if tc.want != "" {
+				pattern = ts + regexp.QuoteMeta(tc.want) + "$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestPrintCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+	l.Print("Ciao")
+	if got := l.DefaultLevel(); got != LevelInfo {
+		t.Errorf("default level: want %v, got %v", LevelInfo, got)
+	}
+
+	pattern := ts + "print_test.go:[0-9]+: " + regexp.QuoteMeta(lp[0]+"Ciao") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		f     func(l *Logger) string
+		want  string
+	}{
+		{"error", LevelInfo, func(l *Logger) string { return l.Sprintf(LevelError, "fmt: %s %v", "ciao", 7) }, lp[2] + "fmt: ciao 7"},
+		{"newline", LevelInfo, func(l *Logger) string { return l.Sprintf(LevelInfo, "Ciao\n") }, lp[0] + "Ciao"},
+		{"fields", LevelInfo, func(l *Logger) string { return l.With("a", 1).Sprintf(LevelWarning, "Ciao") }, lp[1] + "Ciao a=1"},
+		{"tag", LevelInfo, func(l *Logger) string { l.SetTag("[db] "); return l.Sprintf(LevelInfo, "Ciao") }, "[db] " + lp[0] + "Ciao"},
+		{"caller", LevelInfo, func(l *Logger) string { l.Verbose(true); return l.Sprintf(LevelInfo, "Ciao") }, lp[0] + "Ciao"},
+		{"disabled", LevelError, func(l *Logger) string { return l.Sprintf(LevelInfo, "Ciao") }, lp[0] + "Ciao"},
+		{"redacted", LevelInfo, func(l *Logger) string {
+			l.AddRedactor(regexp.MustCompile(`tok_[0-9]+`), "****")
+			return l.With("t", "tok_2").Sprintf(LevelInfo, "auth tok_1")
+		}, lp[0] + "auth **** t=****"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.level, WithWriter(w))
+			got := tc.f(l)
+
+			if got != tc.want {
+				t.Errorf("mismatch! Want %q, got %q", tc.want, got)
+			}
+			if tc.level > LevelInfo {
+				if w.Len() != 0 {
+					t.Errorf("disabled message logged: %q", w.String())
+				}
+				return
+			}
+			pattern := ts + "(print_test.go:[0-9]+: )?" + regexp.QuoteMeta(got) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestLogString(t *testing.T) {
+	tt := []struct {
+		name    string
+		level   string
+		want    string
+		wantErr bool
+	}{
+		{"info", "info", lp[0] + "Ciao7\n", false},
+		{"warning", " WARNING", lp[1] + "Ciao7\n", false},
+		{"error", "ERROR", lp[2] + "Ciao7\n", false},
+		{"disabled", "debug", "", false},
+		{"off", "off", "", false},
+		{"unknown", "notice", lp[1] + "Ciao7\n", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w), WithVerbose(true))
+			l.SetDefaultLevel(LevelWarning)
+			err := l.LogString(tc.level, "Ciao", 7)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("unexpected error value %v", err)
+			}
+			pattern := "^$"
+			if tc.want != "" {
+				pattern = ts + "print_test.go:[0-9]+: " + regexp.QuoteMeta(tc.want) + "$"
+			}
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package log
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ProtoWriter is a RecordSink writing the records as LogRecord protocol
+// buffers messages, each prefixed by its length as a varint, as done by
+// writeDelimitedTo in the protobuf libraries:
+//
+//	message LogRecord {
+//	  int64 time_unix_nano = 1;
+//	  sint32 level = 2; // Severity
+//	  string message = 3;
+//	  repeated Field fields = 4;
+//	  Caller caller = 5;
+//	}
+//
+//	message Field {
+//	  string key = 1;
+//	  string value = 2; // JSON encoding
+//	}
+//
+//	message Caller {
+//	  string file = 1;
+//	  int32 line = 2;
+//	  string func = 3;
+//	}
+//
+// The values of the fields that cannot be encoded to JSON are written as
+// JSON strings in the manner of fmt.Sprint. See ProtoReader to decode them.
+type ProtoWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewProtoWriter returns a ProtoWriter writing to w, e.g. to be added to a
+// logger by AddRecordSink:
+//
+//	l.AddRecordSink(log.NewProtoWriter(f))
+func NewProtoWriter(w io.Writer) *ProtoWriter {
+	return &ProtoWriter{w: w}
+}
+
+// WriteRecord implements RecordSink.
+func (p *ProtoWriter) WriteRecord(r Record) error {
+	msg := marshalRecord(r)
+	p.buf = binary.AppendUvarint(p.buf[:0], uint64(len(msg)))
+	p.buf = append(p.buf, msg...)
+	_, err := p.w.Write(p.buf)
+	return err
+}
+
+// Protocol buffers wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// maxProtoRecord is the size of the largest record ProtoReader accepts.
+const maxProtoRecord = 64 << 20
+
+// marshalRecord returns the LogRecord encoding of r, see ProtoWriter.
+func marshalRecord(r Record) []byte {
+	var b []byte
+	if !r.Time.IsZero() {
+		b = appendTag(b, 1, wireVarint)
+		b = binary.AppendUvarint(b, uint64(r.Time.UnixNano()))
+	}
+	if r.Level != 0 {
+		b = appendTag(b, 2, wireVarint)
+		b = binary.AppendVarint(b, int64(r.Level)) // zigzag, as sint32
+	}
+	if r.Message != "" {
+		b = appendBytes(b, 3, []byte(r.Message))
+	}
+	for _, f := range r.Fields {
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(f.Value)) // #nosec
+		}
+		var fb []byte
+		fb = appendBytes(fb, 1, []byte(f.Key))
+		fb = appendBytes(fb, 2, v)
+		b = appendBytes(b, 4, fb)
+	}
+	if r.Caller != (Caller{}) {
+		var cb []byte
+		if r.Caller.File != "" {
+			cb = appendBytes(cb, 1, []byte(r.Caller.File))
+		}
+		if r.Caller.Line != 0 {
+			cb = appendTag(cb, 2, wireVarint)
+			cb = binary.AppendUvarint(cb, uint64(r.Caller.Line))
+		}
+		if r.Caller.Func != "" {
+			cb = appendBytes(cb, 3, []byte(r.Caller.Func))
+		}
+		b = appendBytes(b, 5, cb)
+	}
+	return b
+}
+
+// appendTag appends the key of the field num of the given wire type.
+func appendTag(b []byte, num, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(num<<3|wire))
+}
+
+// appendBytes appends the field num holding v, length-delimited.
+func appendBytes(b []byte, num int, v []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// ProtoReader decodes the records written by ProtoWriter.
+type ProtoReader struct {
+	r *bufio.Reader
+}
+
+// NewProtoReader returns a ProtoReader reading from r.
+func NewProtoReader(r io.Reader) *ProtoReader {
+	return &ProtoReader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record, with the field values decoded from JSON, or
+// io.EOF at the end of the stream.
+func (p *ProtoReader) Read() (Record, error) {
+	n, err := binary.ReadUvarint(p.r)
+	if err != nil {
+		if err != io.EOF {
+			err = fmt.Errorf("log: reading record length: %w", err)
+		}
+		return Record{}, err
+	}
+	if n > maxProtoRecord {
+		return Record{}, fmt.Errorf("log: record of %d bytes too large", n)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(p.r, msg); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Record{}, fmt.Errorf("log: reading record: %w", err)
+	}
+	return unmarshalRecord(msg)
+}
+
+// errProto is returned for a malformed record.
+var errProto = errors.New("log: malformed protobuf record")
+
+// unmarshalRecord decodes a LogRecord, see ProtoWriter.
+func unmarshalRecord(b []byte) (Record, error) {
+	var r Record
+	err := walkProto(b, func(num, wire int, x uint64, v []byte) error {
+		switch {
+		case num == 1 && wire == wireVarint:
+			r.Time = time.Unix(0, int64(x))
+		case num == 2 && wire == wireVarint:
+			r.Level = Severity(int64(x>>1) ^ -int64(x&1))
+		case num == 3 && wire == wireBytes:
+			r.Message = string(v)
+		case num == 4 && wire == wireBytes:
+			f, err := unmarshalField(v)
+			if err != nil {
+				return err
+			}
+			r.Fields = append(r.Fields, f)
+		case num == 5 && wire == wireBytes:
+			c, err := unmarshalCaller(v)
+			if err != nil {
+				return err
+			}
+			r.Caller = c
+		}
+		return nil
+	})
+	return r, err
+}
+
+// unmarshalCaller decodes a Caller, see ProtoWriter.
+func unmarshalCaller(b []byte) (Caller, error) {
+	var c Caller
+	err := walkProto(b, func(num, wire int, x uint64, v []byte) error {
+		switch {
+		case num == 1 && wire == wireBytes:
+			c.File = string(v)
+		case num == 2 && wire == wireVarint:
+			c.Line = int(int32(x))
+		case num == 3 && wire == wireBytes:
+			c.Func = string(v)
+		}
+		return nil
+	})
+	return c, err
+}
+
+// unmarshalField decodes a Field, see ProtoWriter.
+func unmarshalField(b []byte) (Field, error) {
+	var f Field
+	err := walkProto(b, func(num, wire int, _ uint64, v []byte) error {
+		switch {
+		case num == 1 && wire == wireBytes:
+			f.Key = string(v)
+		case num == 2 && wire == wireBytes:
+			if err := json.Unmarshal(v, &f.Value); err != nil {
+				return fmt.Errorf("log: field value: %w", err)
+			}
+		}
+		return nil
+	})
+	return f, err
+}
+
+// walkProto calls fn for every field of the message b, with its number and
+// wire type, and its value: x for varints and v for the length-delimited
+// fields. The fixed size fields are skipped.
+func walkProto(b []byte, fn func(num, wire int, x uint64, v []byte) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errProto
+		}
+		b = b[n:]
+		num, wire := int(key>>3), int(key&7)
+
+		var x uint64
+		var v []byte
+		switch wire {
+		case wireVarint:
+			if x, n = binary.Uvarint(b); n <= 0 {
+				return errProto
+			}
+			b = b[n:]
+		case wireBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return errProto
+			}
+			v, b = b[n:n+int(size)], b[n+int(size):]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wire == wireFixed32 {
+				size = 4
+			}
+			if len(b) < size {
+				return errProto
+			}
+			b = b[size:]
+			continue
+		default:
+			return errProto
+		}
+		if err := fn(num, wire, x, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
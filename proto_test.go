@@ -0,0 +1,72 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestProtoWriter(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelTrace, WithWriter(io.Discard))
+	l.AddRecordSink(NewProtoWriter(w))
+	before := time.Now()
+	_, file, line, _ := runtime.Caller(0)
+	l.Trace("t")
+	l.With("svc", "api").Infow("served", "n", 7, "m", map[string]interface{}{"a": []int{1}}, "c", complex(1, 2))
+	l.Error("")
+
+	want := []Record{
+		{Level: LevelTrace, Message: "t", Caller: Caller{file, line + 1, "log.TestProtoWriter"}},
+		{Level: LevelInfo, Message: "served", Fields: []Field{
+			{"svc", "api"}, {"n", 7.0}, {"m", map[string]interface{}{"a": []interface{}{1.0}}}, {"c", "(1+2i)"}},
+			Caller: Caller{file, line + 2, "log.TestProtoWriter"}},
+		{Level: LevelError, Caller: Caller{file, line + 3, "log.TestProtoWriter"}},
+	}
+	p := NewProtoReader(w)
+	for i, wr := range want {
+		r, err := p.Read()
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if r.Time.Before(before.Truncate(time.Nanosecond)) || r.Time.After(time.Now()) {
+			t.Errorf("%d: time %v out of range", i, r.Time)
+		}
+		r.Time = time.Time{}
+		if !reflect.DeepEqual(r, wr) {
+			t.Errorf("%d: want %v, got %v", i, wr, r)
+		}
+	}
+	if _, err := p.Read(); err != io.EOF {
+		t.Errorf("want io.EOF, got %v", err)
+	}
+}
+
+func TestProtoReaderErrors(t *testing.T) {
+	var valid bytes.Buffer
+	NewProtoWriter(&valid).WriteRecord(Record{Message: "hi"}) // #nosec
+
+	tt := []struct {
+		name string
+		in   []byte
+		want error
+	}{
+		{"truncated length", []byte{0x80}, io.ErrUnexpectedEOF},
+		{"truncated record", valid.Bytes()[:valid.Len()-1], io.ErrUnexpectedEOF},
+		{"bad wire type", []byte{1, 0x1f}, errProto},
+		{"bad length", []byte{2, 0x1a, 5}, errProto},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewProtoReader(bytes.NewReader(tc.in)).Read()
+			if !errors.Is(err, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
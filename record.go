@@ -0,0 +1,84 @@
+package log
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a logged message with its fields, as given to the record sinks.
+type Record struct {
+	Time    time.Time
+	Level   Severity
+	Message string  // redacted, see AddRedactor
+	Fields  []Field // of the logger and of the message, capped by SetMaxStructDepth and redacted
+	Caller  Caller
+}
+
+// Caller is the location of the code logging a message, rendered by the
+// formatters as the CallerKey and FuncKey fields, and as "file:line: " by the
+// default output.
+type Caller struct {
+	File string // full path, "???" if unknown
+	Line int
+	Func string // qualified by its package name, such as "log.New"
+}
+
+// location returns the "file:line" of c, in the manner of log.Lshortfile, or
+// of log.Llongfile if long.
+func (c Caller) location(long bool) string {
+	file := c.File
+	if i := strings.LastIndexByte(file, '/'); i >= 0 && !long {
+		file = file[i+1:]
+	}
+	return file + ":" + strconv.Itoa(c.Line)
+}
+
+// frameCaller returns the Caller of f.
+func frameCaller(f runtime.Frame) Caller {
+	return Caller{File: f.File, Line: f.Line, Func: frameFunc(f)}
+}
+
+// RecordSink receives the records of the messages logged, see AddRecordSink.
+type RecordSink interface {
+	WriteRecord(r Record) error
+}
+
+// AddRecordSink adds s to the record sinks of the standard logger, see
+// Logger.AddRecordSink.
+func AddRecordSink(s RecordSink) {
+	std.AddRecordSink(s)
+}
+
+// AddRecordSink makes the logger pass the record of every message printed
+// to s, e.g. to keep them in memory or encode them in a binary format,
+// regardless of the writers and formatter. Record sinks are called in the
+// order they were added while the output lock is held, so they must not log
+// through the logger; their errors are ignored.
+// Loggers derived afterwards, e.g. by With, inherit the record sinks.
+func (l *Logger) AddRecordSink(s RecordSink) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.recSinks = append(l.recSinks[:len(l.recSinks):len(l.recSinks)], s)
+}
+
+// writeRecord passes the record of a message to the record sinks and the
+// ring buffer, requiring l.omu to be held. calldepth and pc select the caller
+// as for emit, calldepth being relative to writeRecord.
+func (l *Logger) writeRecord(calldepth int, pc uintptr, level Severity, s string, fields []Field) {
+	r := Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: l.redact(strings.TrimSuffix(s, "\n")),
+		Fields:  l.redactFields(l.limitFields(fields)),
+		Caller:  frameCaller(callerFrame(calldepth, pc)),
+	}
+	if l.ring != nil {
+		l.ring.WriteRecord(r) // #nosec
+	}
+	for _, sink := range l.recSinks {
+		sink.WriteRecord(r) // #nosec
+	}
+}
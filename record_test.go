@@ -0,0 +1,101 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+// records is a RecordSink keeping every record.
+type records []Record
+
+func (rs *records) WriteRecord(r Record) error {
+	*rs = append(*rs, r)
+	return nil
+}
+
+func TestAddRecordSink(t *testing.T) {
+	var rs records
+	l := New(LevelInfo, WithWriter(io.Discard))
+	l.AddRedactor(regexp.MustCompile(`secret`), "****")
+	l.SetMaxStructDepth(1)
+	l.AddRecordSink(&rs)
+	c := l.With("a", 1)
+	l.Debug("hidden")
+	_, file, line, _ := runtime.Caller(0)
+	c.Infow("my secret", "a", 2, "m", map[string]interface{}{"k": []int{1}})
+	l.Error("boom\n")
+
+	want := []Record{
+		{Level: LevelInfo, Message: "my ****", Fields: []Field{{"a", 2}, {"m", map[string]interface{}{"k": "..."}}},
+			Caller: Caller{file, line + 1, "log.TestAddRecordSink"}},
+		{Level: LevelError, Message: "boom", Caller: Caller{file, line + 2, "log.TestAddRecordSink"}},
+	}
+	if len(rs) != len(want) {
+		t.Fatalf("want %d records, got %d: %v", len(want), len(rs), rs)
+	}
+	for i, r := range rs {
+		if r.Time.IsZero() {
+			t.Errorf("%d: no time", i)
+		}
+		r.Time = want[i].Time
+		if !reflect.DeepEqual(r, want[i]) {
+			t.Errorf("%d: want %v, got %v", i, want[i], r)
+		}
+	}
+}
+
+func TestRecordRedacted(t *testing.T) {
+	rec := NewRecorder(10)
+	proto := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(io.Discard))
+	l.AddRedactor(regexp.MustCompile(`(password=)\S+`), "${1}****")
+	l.AddRedactor(regexp.MustCompile(`hunter\d`), "xxx")
+	l.EnableRingBuffer(10, LevelInfo)
+	l.AddRecordSink(rec)
+	l.AddRecordSink(NewProtoWriter(proto))
+	l.With("password", "hunter2").Infow("login", "user", "bob", "note", "was hunter3")
+
+	want := []Field{{"password", "****"}, {"user", "bob"}, {"note", "was xxx"}}
+	r, err := NewProtoReader(proto).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, rs := range map[string][]Record{"ring buffer": l.RingBuffer(), "recorder": rec.Records(), "proto": {r}} {
+		if len(rs) != 1 || !reflect.DeepEqual(rs[0].Fields, want) {
+			t.Errorf("%s: want fields %v, got %v", name, want, rs)
+		}
+	}
+}
+
+func TestRecordCallerFormats(t *testing.T) {
+	text, jsonOut := new(bytes.Buffer), new(bytes.Buffer)
+	var rs records
+	l := New(LevelInfo, WithWriter(text))
+	l.SetTimestamp(false)
+	l.SetCaller(CallerShort)
+	l.SetFormatter(TextFormatter{})
+	l.AddRecordSink(&rs)
+	j := l.WithWriter(jsonOut)
+	j.SetFormatter(JSONFormatter{})
+	_, file, line, _ := runtime.Caller(0)
+	l.Info("Ciao")
+	j.Info("Ciao")
+
+	loc := "record_test.go:" + strconv.Itoa(line+1)
+	if want := loc + ": " + lp[0] + "Ciao\n"; text.String() != want {
+		t.Errorf("text: want %q, got %q", want, text.String())
+	}
+	loc = "record_test.go:" + strconv.Itoa(line+2)
+	if want := `{"level":"info","msg":"Ciao","caller":"` + loc + `","func":"log.TestRecordCallerFormats"}` + "\n"; jsonOut.String() != want {
+		t.Errorf("JSON: want %q, got %q", want, jsonOut.String())
+	}
+	want := []Caller{{file, line + 1, "log.TestRecordCallerFormats"}, {file, line + 2, "log.TestRecordCallerFormats"}}
+	if len(rs) != 2 || rs[0].Caller != want[0] || rs[1].Caller != want[1] {
+		t.Errorf("records: want callers %v, got %v", want, rs)
+	}
+}
@@ -0,0 +1,73 @@
+package log
+
+import (
+	"math"
+	"sync"
+)
+
+// Recorder is a RecordSink keeping the last records, to be re-emitted later
+// through another logger, e.g. to capture the records of a tricky sequence
+// in production and render them locally with colors and the caller:
+//
+//	rec := log.NewRecorder(1000)
+//	l.AddRecordSink(rec)
+//	...
+//	rec.Replay(log.New(log.LevelTrace, log.WithVerbose(true)))
+//
+// A Recorder is safe for concurrent use, and may be shared by several loggers.
+type Recorder struct {
+	mu  sync.Mutex
+	buf ringBuffer
+}
+
+// NewRecorder returns a Recorder keeping the last n records, at least one.
+func NewRecorder(n int) *Recorder {
+	if n < 1 {
+		n = 1
+	}
+	return &Recorder{buf: ringBuffer{recs: make([]Record, 0, n), min: math.MinInt}}
+}
+
+// WriteRecord implements RecordSink.
+func (r *Recorder) WriteRecord(rec Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buf.WriteRecord(rec)
+}
+
+// Records returns the records kept, oldest first.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buf.records()
+}
+
+// Reset drops the records kept.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf.recs, r.buf.next = r.buf.recs[:0], 0
+}
+
+// Replay logs the records kept through l, oldest first, with their level,
+// message and fields, as if they were logged by the caller of Replay. The
+// lines carry the time of the replay, see Records for the original one.
+// Records are logged only if the current logging level of l is equal or less than theirs.
+func (r *Recorder) Replay(l *Logger) {
+	for _, rec := range r.Records() {
+		if l.Enabled(rec.Level) {
+			replay(l, rec)
+		}
+	}
+}
+
+// replay logs rec through l, see Replay.
+func replay(l *Logger, rec Record) {
+	l.omu.Lock()
+	defer l.unlock()
+
+	l.emit(3, 0, rec.Level, "", rec.Message, rec.Fields)
+}
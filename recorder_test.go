@@ -0,0 +1,53 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	tt := []struct {
+		name  string
+		n     int
+		level Severity
+		want  string
+	}{
+		{"all", 10, LevelDebug, "(?s)" + regexp.QuoteMeta(lp[0]+"a n=1") + "\n.*" + regexp.QuoteMeta(lp[1]+"b svc=api") + "\n.*" + regexp.QuoteMeta(dp+"c") + "\n$"},
+		{"bounded", 1, LevelDebug, regexp.QuoteMeta(dp+"c") + "\n$"},
+		{"replay level", 10, LevelWarning, regexp.QuoteMeta(lp[1]+"b svc=api") + "\n$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := NewRecorder(tc.n)
+			l := New(LevelDebug, WithWriter(io.Discard))
+			l.SetFormatter(JSONFormatter{})
+			l.AddRecordSink(rec)
+			l.Infow("a", "n", 1)
+			l.With("svc", "api").Warning("b")
+			l.Debug("c")
+
+			w := new(bytes.Buffer)
+			rec.Replay(New(tc.level, WithWriter(w), WithVerbose(true)))
+			pattern := ts + "recorder_test.go:[0-9]+: " + tc.want
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestRecorderReset(t *testing.T) {
+	rec := NewRecorder(0)
+	rec.WriteRecord(Record{Message: "a"}) // #nosec
+	rec.WriteRecord(Record{Message: "b"}) // #nosec
+	if rs := rec.Records(); len(rs) != 1 || rs[0].Message != "b" {
+		t.Errorf("want the last record, got %v", rs)
+	}
+	rec.Reset()
+	if rs := rec.Records(); len(rs) != 0 {
+		t.Errorf("want no record, got %v", rs)
+	}
+}
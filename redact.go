@@ -0,0 +1,64 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+)
+
+// redactor replaces the matches of re with repl.
+type redactor struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// AddRedactor adds a redactor to the standard logger, see Logger.AddRedactor.
+func AddRedactor(re *regexp.Regexp, replacement string) {
+	std.AddRedactor(re, replacement)
+}
+
+// AddRedactor masks the matches of re in every message, with its fields,
+// before it is written, e.g.
+//
+//	l.AddRedactor(regexp.MustCompile(`(password=)\S+`), "${1}****")
+//
+// turns "login password=hunter2" into "login password=****". replacement is
+// expanded as by regexp.Regexp.ReplaceAllString. With a formatter, the whole
+// formatted line is redacted. Redactors apply in the order they were added,
+// to the hooks and syslog messages as well, and to the records (see
+// AddRecordSink), a field being redacted in its " key=value" form.
+func (l *Logger) AddRedactor(re *regexp.Regexp, replacement string) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.redactors = append(l.redactors[:len(l.redactors):len(l.redactors)], redactor{re: re, repl: replacement})
+}
+
+// redact applies the redactors to s, requiring l.omu to be held.
+func (l *Logger) redact(s string) string {
+	for _, r := range l.redactors {
+		s = r.re.ReplaceAllString(s, r.repl)
+	}
+	return s
+}
+
+// redactFields returns fields with the values redacted as in the text output,
+// in their " key=value" form, fields itself without redactors. A field whose
+// text is changed gets the redacted text after "key=" as value.
+// It requires l.omu to be held.
+func (l *Logger) redactFields(fields []Field) []Field {
+	if l.redactors == nil || len(fields) == 0 {
+		return fields
+	}
+	redacted := make([]Field, len(fields))
+	for i, f := range fields {
+		text := fieldsText(fields[i : i+1])
+		if s := l.redact(text); s != text {
+			if v, ok := strings.CutPrefix(s, " "+f.Key+"="); ok {
+				s = v
+			}
+			f.Value = strings.TrimPrefix(s, " ")
+		}
+		redacted[i] = f
+	}
+	return redacted
+}
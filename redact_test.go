@@ -0,0 +1,71 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAddRedactor(t *testing.T) {
+	token := regexp.MustCompile(`tok_[0-9a-f]+`)
+	password := regexp.MustCompile(`(password=)\S+`)
+
+	tt := []struct {
+		name string
+		f    func(l *Logger)
+		want string
+	}{
+		{"token", func(l *Logger) { l.AddRedactor(token, "****"); l.Info("auth with tok_12ab34 done") }, ts + regexp.QuoteMeta(lp[0]+"auth with **** done")},
+		{"several matches", func(l *Logger) { l.AddRedactor(token, "****"); l.Info("tok_1 and tok_2") }, ts + regexp.QuoteMeta(lp[0]+"**** and ****")},
+		{"field", func(l *Logger) {
+			l.AddRedactor(password, "${1}****")
+			l.Infow("login", "user", "bob", "password", "hunter2")
+		}, ts + regexp.QuoteMeta(lp[0]+"login user=bob password=****")},
+		{"logger field", func(l *Logger) { l.AddRedactor(password, "${1}****"); l.With("password", "hunter2").Info("login") }, ts + regexp.QuoteMeta(lp[0]+"login password=****")},
+		{"order", func(l *Logger) {
+			l.AddRedactor(token, "tok_secret")
+			l.AddRedactor(regexp.MustCompile(`secret`), "****")
+			l.Info("tok_1")
+		}, ts + regexp.QuoteMeta(lp[0]+"tok_****")},
+		{"header untouched", func(l *Logger) {
+			l.SetLineWrap("INFO ", " INFO")
+			l.AddRedactor(regexp.MustCompile(`INFO`), "****")
+			l.Info("INFO")
+		}, "^INFO " + ts[1:] + regexp.QuoteMeta(lp[0]+"**** INFO")},
+		{"formatter", func(l *Logger) {
+			l.SetFormatter(JSONFormatter{})
+			l.AddRedactor(regexp.MustCompile(`("password":)"[^"]*"`), `$1"****"`)
+			l.SetTimestamp(false)
+			l.Infow("login", "password", "hunter2")
+		}, "^" + regexp.QuoteMeta(`{"level":"info","msg":"login","password":"****"}`)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			tc.f(l)
+
+			pattern := tc.want + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+			if strings.Contains(w.String(), "hunter2") || strings.Contains(w.String(), "tok_1") {
+				t.Errorf("secret leaked: %q", w.String())
+			}
+		})
+	}
+}
+
+func TestAddRedactorHooks(t *testing.T) {
+	l := New(LevelInfo, WithWriter(new(bytes.Buffer)))
+	h := &recordHook{levels: []Severity{LevelInfo}}
+	l.AddHook(h)
+	l.AddRedactor(regexp.MustCompile(`hunter2`), "****")
+	l.Infow("login", "password", "hunter2")
+
+	if want := "INFO login password=****"; len(h.fired) != 1 || h.fired[0] != want {
+		t.Errorf("mismatch! Want %q, got %q", want, h.fired)
+	}
+}
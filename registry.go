@@ -0,0 +1,66 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// Config is the configuration of a named logger, see Configure.
+type Config struct {
+	Level     Severity    // minimum level to print
+	Verbose   bool        // add file and line number
+	Formatter Formatter   // line format, the default text one if nil
+	Writer    io.Writer   // output stream
+	Writers   []io.Writer // further output streams, see AddWriter
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Logger{}
+)
+
+// Get returns the logger registered under name, creating it with LevelInfo
+// if it does not exist yet.
+func Get(name string) *Logger {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	return get(name)
+}
+
+// Configure applies cfg to the loggers registered under its keys, creating
+// the missing ones. Existing loggers are updated in place, so loggers
+// previously returned by Get pick up the new configuration.
+// The logger writes to Writer and Writers, replacing its writers, or keeps
+// its writers if both are empty.
+func Configure(cfg map[string]Config) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for name, c := range cfg {
+		l := get(name)
+		l.SetLevel(c.Level)
+		l.Verbose(c.Verbose)
+		l.SetFormatter(c.Formatter)
+		ws := c.Writers
+		if c.Writer != nil {
+			ws = append([]io.Writer{c.Writer}, ws...)
+		}
+		if len(ws) > 0 {
+			l.SetWriter(ws[0])
+			for _, w := range ws[1:] {
+				l.AddWriter(w)
+			}
+		}
+	}
+}
+
+// get is like Get but requires registryMu to be held.
+func get(name string) *Logger {
+	l, ok := registry[name]
+	if !ok {
+		l = New(LevelInfo)
+		registry[name] = l
+	}
+	return l
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	l := Get("get")
+	if l == nil {
+		t.Fatal("nil logger")
+	}
+	if Get("get") != l {
+		t.Error("Get returned a different logger for the same name")
+	}
+	if Get("other") == l {
+		t.Error("Get returned the same logger for different names")
+	}
+	if l.Level() != LevelInfo || l.Writer() != os.Stdout {
+		t.Errorf("unexpected defaults: level %v, writer %v", l.Level(), l.Writer())
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	db := Get("db")
+	w1, w2 := new(bytes.Buffer), new(bytes.Buffer)
+
+	Configure(map[string]Config{
+		"db":   {Level: LevelError, Writer: w1},
+		"http": {Level: LevelWarning, Verbose: true, Writer: w2},
+	})
+
+	if Get("db") != db {
+		t.Fatal("Configure replaced an existing logger")
+	}
+	if db.Level() != LevelError || db.Writer() != w1 {
+		t.Errorf("db not configured: level %v", db.Level())
+	}
+	h := Get("http")
+	if h.Level() != LevelWarning || h.Writer() != w2 || h.headerFlags()&log.Lshortfile == 0 {
+		t.Errorf("http not configured: level %v, flags %b", h.Level(), h.headerFlags())
+	}
+
+	Configure(map[string]Config{"db": {Level: LevelInfo}})
+	if db.Level() != LevelInfo || db.Writer() != w1 {
+		t.Errorf("db not reconfigured in place: level %v", db.Level())
+	}
+}
+
+func TestConfigureFormatterWriters(t *testing.T) {
+	w1, w2, w3 := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+
+	Configure(map[string]Config{
+		"jobs": {Level: LevelInfo, Formatter: JSONFormatter{}, Writer: w1, Writers: []io.Writer{w2, w3}},
+	})
+	l := Get("jobs")
+	l.SetTimestamp(false)
+	l.Info("Ciao")
+
+	want := `{"level":"info","msg":"Ciao"}` + "\n"
+	for i, w := range []*bytes.Buffer{w1, w2, w3} {
+		if w.String() != want {
+			t.Errorf("writer %d: want %q, got %q", i, want, w.String())
+		}
+	}
+
+	Configure(map[string]Config{"jobs": {Level: LevelInfo, Writers: []io.Writer{w3}}})
+	w3.Reset()
+	l.Info("Ciao")
+	if want := lp[0] + "Ciao\n"; w3.String() != want || len(l.Writers()) != 1 {
+		t.Errorf("not reconfigured: want %q, got %q", want, w3.String())
+	}
+}
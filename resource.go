@@ -0,0 +1,53 @@
+package log
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// resource holds the resource labels appended to every line.
+var resource atomic.Value // of resourceLabels
+
+// resourceLabels are the resource labels as a field and rendered.
+type resourceLabels struct {
+	fields []Field // a single ResourceKey field, if any label
+	text   string
+}
+
+// SetResourceLabels attaches labels describing the running process (e.g. pod,
+// namespace and node, as injected by Kubernetes) to every line of every
+// logger, appended to the message as " key=value" pairs sorted by key.
+// Formatters get them as a single ResourceKey field holding a
+// map[string]string, which JSONFormatter renders as a nested object.
+// The labels are rendered once; a nil or empty map removes them.
+func SetResourceLabels(labels map[string]string) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kv := make([]interface{}, 0, 2*len(keys))
+	m := make(map[string]string, len(keys))
+	for _, k := range keys {
+		kv = append(kv, k, labels[k])
+		m[k] = labels[k]
+	}
+	var fields []Field
+	if len(m) > 0 {
+		fields = []Field{{Key: ResourceKey, Value: m}}
+	}
+	resource.Store(resourceLabels{fields: fields, text: formatFields(kv)})
+}
+
+// resourceFields returns the rendered resource labels.
+func resourceFields() string {
+	r, _ := resource.Load().(resourceLabels)
+	return r.text
+}
+
+// resourceList returns the resource labels as a ResourceKey field, if any.
+func resourceList() []Field {
+	r, _ := resource.Load().(resourceLabels)
+	return r.fields
+}
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestResourceLabels(t *testing.T) {
+	defer SetResourceLabels(nil)
+
+	tt := []struct {
+		name   string
+		labels map[string]string
+		f      func(l *Logger)
+		want   string
+	}{
+		{"none", nil, func(l *Logger) { l.Info("Ciao") }, lp[0] + "Ciao"},
+		{"sorted", map[string]string{"pod": "web-1", "namespace": "prod"}, func(l *Logger) { l.Info("Ciao") }, lp[0] + "Ciao namespace=prod pod=web-1"},
+		{"trailing newline", map[string]string{"pod": "web-1"}, func(l *Logger) { l.Errorf("Ciao\n") }, lp[2] + "Ciao pod=web-1"},
+		{"removed", map[string]string{}, func(l *Logger) { l.Warning("Ciao") }, lp[1] + "Ciao"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo)
+			l.SetWriter(w)
+			SetResourceLabels(tc.labels)
+			tc.f(l)
+
+			pattern := ts + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestResourceLabelsLineWrap(t *testing.T) {
+	defer SetResourceLabels(nil)
+
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetLineWrap("", " <<<")
+	SetResourceLabels(map[string]string{"node": "n1"})
+	l.Info("Ciao")
+
+	pattern := ts + regexp.QuoteMeta(lp[0]+"Ciao node=n1 <<<") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
+
+func TestResourceLabelsFormatter(t *testing.T) {
+	defer SetResourceLabels(nil)
+
+	tt := []struct {
+		name string
+		f    Formatter
+		want string
+	}{
+		{"JSON", JSONFormatter{}, `{"level":"info","msg":"Ciao","k":1,"fields.resource":"x","resource":{"namespace":"prod","pod":"web-1"}}` + "\n"},
+		{"text", TextFormatter{}, lp[0] + "Ciao k=1 resource=x namespace=prod pod=web-1\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetTimestamp(false)
+			l.SetFormatter(tc.f)
+			SetResourceLabels(map[string]string{"pod": "web-1", "namespace": "prod"})
+			l.Infow("Ciao", "k", 1, "resource", "x")
+
+			if w.String() != tc.want {
+				t.Errorf("mismatch! Want %q, got %q", tc.want, w.String())
+			}
+		})
+	}
+}
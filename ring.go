@@ -0,0 +1,73 @@
+package log
+
+// EnableRingBuffer keeps the last records of the standard logger in memory,
+// see Logger.EnableRingBuffer.
+func EnableRingBuffer(n int, min Severity) {
+	std.EnableRingBuffer(n, min)
+}
+
+// RingBuffer returns the records kept by the standard logger, see
+// Logger.RingBuffer.
+func RingBuffer() []Record {
+	return std.RingBuffer()
+}
+
+// EnableRingBuffer keeps in memory the records (see Record) of the last n
+// messages printed at or above min, e.g. to dump the recent warnings and
+// errors after a crash without the debug noise:
+//
+//	l.EnableRingBuffer(100, LevelWarning)
+//
+// The records kept so far are dropped. A value of n less than or equal to
+// zero disables the ring buffer (default).
+// Loggers derived afterwards, e.g. by With, share the ring buffer.
+func (l *Logger) EnableRingBuffer(n int, min Severity) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.ring = nil
+	if n > 0 {
+		l.ring = &ringBuffer{recs: make([]Record, 0, n), min: min}
+	}
+}
+
+// RingBuffer returns the records kept by the ring buffer, oldest first, nil
+// if it is disabled, see EnableRingBuffer.
+func (l *Logger) RingBuffer() []Record {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	if l.ring == nil {
+		return nil
+	}
+	return l.ring.records()
+}
+
+// ringBuffer is a RecordSink keeping the last records at or above min,
+// guarded by the omu of its logger.
+type ringBuffer struct {
+	recs []Record
+	next int // index of the oldest record once recs is full
+	min  Severity
+}
+
+// WriteRecord implements RecordSink.
+func (b *ringBuffer) WriteRecord(r Record) error {
+	if r.Level < b.min {
+		return nil
+	}
+	if len(b.recs) < cap(b.recs) {
+		b.recs = append(b.recs, r)
+		return nil
+	}
+	b.recs[b.next] = r
+	b.next = (b.next + 1) % len(b.recs)
+	return nil
+}
+
+// records returns a copy of the records kept, oldest first.
+func (b *ringBuffer) records() []Record {
+	recs := make([]Record, 0, len(b.recs))
+	recs = append(recs, b.recs[b.next:]...)
+	return append(recs, b.recs[:b.next]...)
+}
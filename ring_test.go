@@ -0,0 +1,44 @@
+package log
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEnableRingBuffer(t *testing.T) {
+	tt := []struct {
+		name string
+		n    int
+		min  Severity
+		want []string
+	}{
+		{"disabled", 0, LevelTrace, nil},
+		{"all", 10, LevelTrace, []string{"d1", "i1", "w1", "e1", "i2", "w2"}},
+		{"last", 3, LevelTrace, []string{"e1", "i2", "w2"}},
+		{"warnings", 10, LevelWarning, []string{"w1", "e1", "w2"}},
+		{"last warnings", 2, LevelWarning, []string{"e1", "w2"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New(LevelDebug, WithWriter(io.Discard))
+			l.EnableRingBuffer(tc.n, tc.min)
+			l.Trace("t1")
+			l.Debug("d1")
+			l.Info("i1")
+			l.Warning("w1")
+			l.With("k", 1).Error("e1")
+			l.Info("i2")
+			l.Warning("w2")
+
+			var got []string
+			for _, r := range l.RingBuffer() {
+				got = append(got, r.Message)
+			}
+			if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
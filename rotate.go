@@ -0,0 +1,272 @@
+package log
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingWriter is a writer appending to a file, rolled over once it would
+// grow beyond a size: path is renamed to path.1, path.1 to path.2 and so on,
+// the oldest archive being deleted, and a new path is created.
+type RotatingWriter struct {
+	path     string
+	maxBytes int64
+	maxFiles int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// NewRotatingWriter returns a writer appending to path, rotated before a
+// write would make it exceed maxBytes and keeping at most maxFiles archives.
+// The file is opened, or created, on the first write.
+// A maxBytes less than or equal to zero means no rotation.
+func NewRotatingWriter(path string, maxBytes int64, maxFiles int) *RotatingWriter {
+	return &RotatingWriter{path: path, maxBytes: maxBytes, maxFiles: maxFiles}
+}
+
+// Write appends p to the file, rotating it first if p does not fit.
+// A write larger than maxBytes goes to a file of its own.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.maxBytes > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// ConcurrentSafe implements ConcurrentWriter.
+func (w *RotatingWriter) ConcurrentSafe() {}
+
+// Flush commits the file contents to stable storage.
+func (w *RotatingWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	return w.f.Sync()
+}
+
+// Close closes the file. A following write opens it again.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
+
+// open opens the file for appending, requiring w.mu to be held.
+func (w *RotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close() // #nosec
+		return err
+	}
+	w.f, w.size = f, fi.Size()
+	return nil
+}
+
+// rotate shifts the archives, archives the file and opens a new one,
+// requiring w.mu to be held.
+func (w *RotatingWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	w.f = nil
+
+	if w.maxFiles <= 0 {
+		if err := os.Remove(w.path); err != nil {
+			return err
+		}
+		return w.open()
+	}
+	os.Remove(w.archive(w.maxFiles)) // #nosec, may not exist
+	for i := w.maxFiles - 1; i > 0; i-- {
+		err := os.Rename(w.archive(i), w.archive(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(w.path, w.archive(1)); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// archive returns the path of the i-th archive.
+func (w *RotatingWriter) archive(i int) string {
+	return w.path + "." + strconv.Itoa(i)
+}
+
+// TimeRotatingWriter is a writer appending to a file per time period, named
+// after the start of the period, e.g. app-2024-01-02.log for a daily file.
+type TimeRotatingWriter struct {
+	dir      string
+	base     string
+	interval time.Duration
+
+	mu     sync.Mutex
+	now    func() time.Time
+	keep   int
+	f      *os.File
+	period time.Time // start of the period of f
+}
+
+// NewTimeRotatingWriter returns a writer appending to a new file in dir every
+// interval. With an interval of 24 hours or less than or equal to zero, files
+// are rotated at local midnight and named base-2006-01-02.ext, base being
+// "name.ext"; with other intervals periods start at multiples of interval
+// since the zero time and the name includes the time of day,
+// base-2006-01-02T150405.ext.
+func NewTimeRotatingWriter(dir, base string, interval time.Duration) *TimeRotatingWriter {
+	return &TimeRotatingWriter{dir: dir, base: base, interval: interval, now: time.Now}
+}
+
+// SetClock sets the function returning the current time, time.Now by default.
+func (w *TimeRotatingWriter) SetClock(now func() time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.now = now
+}
+
+// SetRetention sets how many files are kept, the current one included; the
+// oldest ones are deleted on rotation. Zero, the default, keeps them all.
+func (w *TimeRotatingWriter) SetRetention(n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.keep = n
+}
+
+// Write appends p to the file of the current period, rotating it if the
+// period changed.
+func (w *TimeRotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if period := w.start(w.now()); w.f == nil || !period.Equal(w.period) {
+		if err := w.rotate(period); err != nil {
+			return 0, err
+		}
+	}
+	return w.f.Write(p)
+}
+
+// ConcurrentSafe implements ConcurrentWriter.
+func (w *TimeRotatingWriter) ConcurrentSafe() {}
+
+// Flush commits the file contents to stable storage.
+func (w *TimeRotatingWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	return w.f.Sync()
+}
+
+// Close closes the file. A following write opens it again.
+func (w *TimeRotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
+
+// daily reports whether files are rotated at local midnight.
+func (w *TimeRotatingWriter) daily() bool {
+	return w.interval <= 0 || w.interval == 24*time.Hour
+}
+
+// start returns the start of the period including t.
+func (w *TimeRotatingWriter) start(t time.Time) time.Time {
+	if w.daily() {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(w.interval)
+}
+
+// name returns the file name of the period starting at t.
+func (w *TimeRotatingWriter) name(t time.Time) string {
+	layout := "2006-01-02T150405"
+	if w.daily() {
+		layout = "2006-01-02"
+	}
+	ext := filepath.Ext(w.base)
+	return strings.TrimSuffix(w.base, ext) + "-" + t.Format(layout) + ext
+}
+
+// rotate closes the current file, opens the one of period and deletes the
+// files beyond the retention, requiring w.mu to be held.
+func (w *TimeRotatingWriter) rotate(period time.Time) error {
+	if w.f != nil {
+		if err := w.f.Close(); err != nil {
+			return err
+		}
+		w.f = nil
+	}
+	f, err := os.OpenFile(filepath.Join(w.dir, w.name(period)), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	w.f, w.period = f, period
+	if w.keep > 0 {
+		return w.prune()
+	}
+	return nil
+}
+
+// prune deletes the oldest files beyond the retention, requiring w.mu to be
+// held. The names sort in time order.
+func (w *TimeRotatingWriter) prune() error {
+	ext := filepath.Ext(w.base)
+	pattern := filepath.Join(w.dir, strings.TrimSuffix(w.base, ext)+"-[0-9]*"+ext)
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	var errs []error
+	for len(files) > w.keep {
+		errs = append(errs, os.Remove(files[0]))
+		files = files[1:]
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,144 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingWriter(t *testing.T) {
+	tt := []struct {
+		name     string
+		maxFiles int
+		want     map[string]string // file name to contents
+	}{
+		{"archives", 3, map[string]string{"app.log": "three\n", "app.log.1": "two\n", "app.log.2": "one\n"}},
+		{"oldest deleted", 1, map[string]string{"app.log": "three\n", "app.log.1": "two\n"}},
+		{"no archive", 0, map[string]string{"app.log": "three\n"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			w := NewRotatingWriter(filepath.Join(dir, "app.log"), 6, tc.maxFiles)
+			for _, line := range []string{"one\n", "two\n", "three\n"} {
+				if n, err := w.Write([]byte(line)); n != len(line) || err != nil {
+					t.Fatalf("want %d, nil, got %d, %v", len(line), n, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, e := range entries {
+				names = append(names, e.Name())
+			}
+			if len(names) != len(tc.want) {
+				t.Fatalf("want %d files, got %q", len(tc.want), names)
+			}
+			for name, want := range tc.want {
+				b, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if string(b) != want {
+					t.Errorf("%s: want %q, got %q", name, want, b)
+				}
+			}
+		})
+	}
+}
+
+func TestRotatingWriterLogger(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 99)+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := New(LevelInfo)
+	l.SetWriter(NewRotatingWriter(path, 130, 5))
+	for i := 0; i < 3; i++ {
+		l.Info("Ciao")
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, err := filepath.Glob(path + "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("want the existing file archived, got %q", files)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(b), lp[0]+"Ciao\n"); got != 3 {
+		t.Errorf("want 3 lines in the live file, got %q", b)
+	}
+}
+
+func TestTimeRotatingWriter(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2024, 1, d, h, 30, 0, 0, time.UTC) }
+	tt := []struct {
+		name     string
+		interval time.Duration
+		keep     int
+		times    []time.Time
+		want     map[string]string // file name to contents
+	}{
+		{"daily", 24 * time.Hour, 0, []time.Time{day(1, 10), day(1, 23), day(2, 0), day(3, 12)},
+			map[string]string{"app-2024-01-01.log": "0\n1\n", "app-2024-01-02.log": "2\n", "app-2024-01-03.log": "3\n"}},
+		{"retention", 0, 2, []time.Time{day(1, 10), day(2, 10), day(3, 10), day(4, 10)},
+			map[string]string{"app-2024-01-03.log": "2\n", "app-2024-01-04.log": "3\n"}},
+		{"hourly", time.Hour, 0, []time.Time{day(1, 10), day(1, 10).Add(10 * time.Minute), day(1, 11)},
+			map[string]string{"app-2024-01-01T100000.log": "0\n1\n", "app-2024-01-01T110000.log": "2\n"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			w := NewTimeRotatingWriter(dir, "app.log", tc.interval)
+			w.SetRetention(tc.keep)
+			var now time.Time
+			w.SetClock(func() time.Time { return now })
+			for i, ts := range tc.times {
+				now = ts
+				if _, err := fmt.Fprintln(w, i); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(tc.want) {
+				t.Fatalf("want %d files, got %v", len(tc.want), entries)
+			}
+			for name, want := range tc.want {
+				b, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				if string(b) != want {
+					t.Errorf("%s: want %q, got %q", name, want, b)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package log
+
+import "time"
+
+// SamplerPolicy is how a level sampler throttles the messages, see
+// SetLevelSampler.
+type SamplerPolicy int
+
+const (
+	// SampleOff logs every message (default).
+	SampleOff SamplerPolicy = iota
+	// SampleRate logs the first message and then one in SamplerConfig.Every.
+	SampleRate
+	// SampleTokenBucket logs bursts of up to SamplerConfig.Burst messages,
+	// refilled at SamplerConfig.Rate messages per second.
+	SampleTokenBucket
+	// SampleKeyed is like SampleTokenBucket with a bucket per message key,
+	// see SamplerConfig.Key, so that a noisy message doesn't throttle the
+	// others.
+	SampleKeyed
+)
+
+// maxSamplerKeys is the number of keys above which SampleKeyed forgets the
+// keys with a full bucket.
+const maxSamplerKeys = 1000
+
+// SamplerConfig is the policy of a level sampler and its parameters.
+type SamplerConfig struct {
+	Policy SamplerPolicy
+	Every  int                     // SampleRate: one message logged in Every
+	Rate   float64                 // SampleTokenBucket and SampleKeyed: refill in messages per second
+	Burst  int                     // SampleTokenBucket and SampleKeyed: bucket size, 1 if less
+	Key    func(msg string) string // SampleKeyed: key of a message, the message itself if nil
+}
+
+// SetLevelSampler sets the sampler of the messages of the given level of the
+// standard logger, see Logger.SetLevelSampler.
+func SetLevelSampler(level Severity, cfg SamplerConfig) {
+	std.SetLevelSampler(level, cfg)
+}
+
+// SetLevelSampler throttles the messages of the given level as set by cfg,
+// replacing the previous sampler of that level, e.g. to throttle Info
+// messages while letting Warning ones through in short bursts:
+//
+//	l.SetLevelSampler(LevelInfo, SamplerConfig{Policy: SampleRate, Every: 100})
+//	l.SetLevelSampler(LevelWarning, SamplerConfig{Policy: SampleTokenBucket, Rate: 1, Burst: 10})
+//
+// No level is sampled by default, Error included. The level of the logger
+// (see SetLevel) applies first, so disabled messages don't count against the
+// sampler; the messages of Aggregate are sampled as any other. A dropped
+// message reaches neither the writers nor the hooks.
+// The derived loggers (see With) share the samplers set beforehand.
+func (l *Logger) SetLevelSampler(level Severity, cfg SamplerConfig) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	samplers := make(map[Severity]*sampler, len(l.samplers)+1)
+	for k, s := range l.samplers {
+		samplers[k] = s
+	}
+	if cfg.Policy == SampleOff {
+		delete(samplers, level)
+	} else {
+		samplers[level] = &sampler{cfg: cfg}
+	}
+	if len(samplers) == 0 {
+		samplers = nil
+	}
+	l.samplers = samplers
+}
+
+// sampler is the state of a level sampler, guarded by the omu of its logger.
+type sampler struct {
+	cfg     SamplerConfig
+	n       int // messages seen, for SampleRate
+	bucket  bucket
+	buckets map[string]*bucket // for SampleKeyed
+}
+
+// bucket is a token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time // zero until first used
+}
+
+// allow reports whether msg is to be logged.
+func (s *sampler) allow(msg string) bool {
+	switch s.cfg.Policy {
+	case SampleRate:
+		s.n++
+		return s.cfg.Every <= 1 || (s.n-1)%s.cfg.Every == 0
+	case SampleTokenBucket:
+		return s.bucket.take(s.cfg, time.Now())
+	case SampleKeyed:
+		key := msg
+		if s.cfg.Key != nil {
+			key = s.cfg.Key(msg)
+		}
+		now := time.Now()
+		b, ok := s.buckets[key]
+		if !ok {
+			if len(s.buckets) >= maxSamplerKeys {
+				s.forget(now)
+			}
+			if s.buckets == nil {
+				s.buckets = map[string]*bucket{}
+			}
+			b = new(bucket)
+			s.buckets[key] = b
+		}
+		return b.take(s.cfg, now)
+	}
+	return true
+}
+
+// forget drops the buckets refilled by now, as they behave as new ones.
+func (s *sampler) forget(now time.Time) {
+	for key, b := range s.buckets {
+		b.refill(s.cfg, now)
+		if b.tokens >= float64(burst(s.cfg)) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// take takes a token from b, reporting whether there was one.
+func (b *bucket) take(cfg SamplerConfig, now time.Time) bool {
+	b.refill(cfg, now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill adds to b the tokens earned since it was last used.
+func (b *bucket) refill(cfg SamplerConfig, now time.Time) {
+	max := float64(burst(cfg))
+	if b.last.IsZero() {
+		b.tokens = max
+	} else if b.tokens += now.Sub(b.last).Seconds() * cfg.Rate; b.tokens > max {
+		b.tokens = max
+	}
+	b.last = now
+}
+
+// burst returns the bucket size of cfg.
+func burst(cfg SamplerConfig) int {
+	if cfg.Burst < 1 {
+		return 1
+	}
+	return cfg.Burst
+}
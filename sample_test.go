@@ -0,0 +1,71 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSetLevelSampler(t *testing.T) {
+	tt := []struct {
+		name  string
+		level Severity
+		cfg   SamplerConfig
+		msgs  []string
+		want  []string
+	}{
+		{"off", LevelInfo, SamplerConfig{}, []string{"a", "a", "a"}, []string{"a", "a", "a"}},
+		{"rate", LevelInfo, SamplerConfig{Policy: SampleRate, Every: 2}, []string{"a", "b", "c", "d", "e"}, []string{"a", "c", "e"}},
+		{"token bucket", LevelWarning, SamplerConfig{Policy: SampleTokenBucket, Rate: 0.001, Burst: 2}, []string{"a", "b", "c", "d"}, []string{"a", "b"}},
+		{"keyed", LevelInfo, SamplerConfig{Policy: SampleKeyed, Rate: 0.001}, []string{"a", "b", "a", "c", "b"}, []string{"a", "b", "c"}},
+		{"custom key", LevelInfo, SamplerConfig{Policy: SampleKeyed, Rate: 0.001, Key: func(msg string) string { return msg[:1] }}, []string{"a1", "b1", "a2"}, []string{"a1", "b1"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelInfo, WithWriter(w))
+			l.SetLevelSampler(tc.level, tc.cfg)
+			for _, msg := range tc.msgs {
+				l.Output(1, tc.level, msg)
+				l.Error(msg) // never sampled by default
+			}
+
+			var got []string
+			for _, line := range strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n") {
+				if !strings.Contains(line, lp[2]) {
+					got = append(got, line[strings.LastIndex(line, " ")+1:])
+				}
+			}
+			if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+			if n := strings.Count(w.String(), lp[2]); n != len(tc.msgs) {
+				t.Errorf("want %d error messages, got %d", len(tc.msgs), n)
+			}
+		})
+	}
+}
+
+func TestSetLevelSamplerShared(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetLevelSampler(LevelInfo, SamplerConfig{Policy: SampleTokenBucket, Rate: 0.001})
+	c := l.With("k", 1)
+	l.Info("a")
+	c.Info("b")
+	l.Debug("c") // disabled, not counted
+
+	pattern := ts + regexp.QuoteMeta(lp[0]+"a") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+
+	l.SetLevelSampler(LevelInfo, SamplerConfig{Policy: SampleOff})
+	w.Reset()
+	l.Info("d")
+	if !strings.Contains(w.String(), lp[0]+"d") {
+		t.Errorf("not logged after SampleOff: %q", w.String())
+	}
+}
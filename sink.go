@@ -0,0 +1,296 @@
+package log
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// WriteFlushCloser is implemented by sinks buffering output, such as
+// compressed, encrypted or network writers. Flush must deliver any buffered
+// data and Close must flush and release the sink's resources.
+//
+// Logger.Flush and Logger.Close drive the sinks implementing it; any other
+// io.Writer is left alone, except for Flush which also drives writers
+// providing just a Flush() error method (e.g. *bufio.Writer).
+//
+// The loggers never call Write, Flush, Close or Sync of a sink concurrently,
+// even when it is shared by several loggers, so sinks need no locking of their
+// own unless they are written to directly, see SyncWriter. Unless a sink is
+// known to be safe for concurrent use (see ConcurrentWriter), the calls to it
+// are serialized with those to every other such sink; it must then not log
+// through a logger from these calls.
+type WriteFlushCloser interface {
+	io.Writer
+	Flush() error
+	Close() error
+}
+
+// ConcurrentWriter is implemented by the sinks safe for concurrent use, such
+// as those of this package, which the loggers then call without locking,
+// see WriteFlushCloser. *os.File and io.Discard are known to be safe.
+type ConcurrentWriter interface {
+	io.Writer
+	// ConcurrentSafe only marks the writer as safe for concurrent use.
+	ConcurrentSafe()
+}
+
+// sinkMu serializes the calls to the sinks not known to be safe for
+// concurrent use, see WriteFlushCloser. It is acquired after the output lock
+// of a logger, never before.
+var sinkMu sync.Mutex
+
+// lockSink locks sinkMu unless w is known to be safe for concurrent use, or is
+// a writer of the logger itself locking its sinks, and returns the function
+// undoing it.
+func lockSink(w io.Writer) (unlock func()) {
+	switch w.(type) {
+	case ConcurrentWriter, *os.File, *multiWriter, *asyncWriter, *pauseBuffer, pauseErr:
+		return func() {}
+	}
+	if w == io.Discard {
+		return func() {}
+	}
+	sinkMu.Lock()
+	return sinkMu.Unlock
+}
+
+// writeSink writes p to the sink w, see lockSink.
+func writeSink(w io.Writer, p []byte) (int, error) {
+	defer lockSink(w)()
+	return w.Write(p)
+}
+
+// Flush flushes the standard logger sinks.
+func Flush() error {
+	return std.Flush()
+}
+
+// Close flushes and closes the standard logger sinks.
+func Close() error {
+	return std.Close()
+}
+
+// Sync flushes the standard logger sinks and commits them to stable storage,
+// see Logger.Sync.
+func Sync() error {
+	return std.Sync()
+}
+
+// Sync flushes the logger sinks (see Flush), then commits to stable storage
+// the ones having a Sync() error method, such as *os.File; e.g. before the
+// program exits. Sinks with neither method are left alone.
+// As Flush, Sync first blocks until the lines queued by asynchronous output
+// (see SetAsync) are written, so that tests can assert on the output of an
+// asynchronous logger without sleeping; with synchronous output and plain
+// writers, such as a bytes.Buffer, it does nothing. Flush stops there, only
+// delivering what the sinks buffer, while Sync also waits for the storage.
+// Errors from every sink are joined together.
+func (l *Logger) Sync() error {
+	return errors.Join(l.Flush(), l.syncSinks())
+}
+
+// Flush delivers the data buffered by the logger sinks, after the lines
+// queued by asynchronous output (see SetAsync).
+// Errors from every sink are joined together.
+func (l *Logger) Flush() error {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	if l.async != nil {
+		l.async.drain()
+	}
+	var errs []error
+	for _, w := range l.sinks() {
+		if f, ok := w.(interface{ Flush() error }); ok {
+			unlock := lockSink(w)
+			errs = append(errs, f.Flush())
+			unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the logger heartbeats, logs the pending aggregates, restores
+// synchronous output (see SetAsync) and closes the logger sinks implementing
+// WriteFlushCloser, and syslog (see SetSyslog); any other sink, such as
+// os.Stdout, is left open.
+// Errors from every sink are joined together.
+func (l *Logger) Close() error {
+	l.stopHeartbeats()
+	l.flushAggregates()
+
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	l.stopAsync()
+	var errs []error
+	for _, w := range l.sinks() {
+		if c, ok := w.(WriteFlushCloser); ok {
+			unlock := lockSink(w)
+			errs = append(errs, c.Close())
+			unlock()
+		}
+	}
+	if l.sys != nil {
+		errs = append(errs, l.sys.Close())
+		l.sys = nil
+	}
+	return errors.Join(errs...)
+}
+
+// syncSinks commits to stable storage the sinks having a Sync() error
+// method, such as *os.File. Errors from every sink are joined together.
+func (l *Logger) syncSinks() error {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	var errs []error
+	for _, w := range l.sinks() {
+		if s, ok := w.(interface{ Sync() error }); ok {
+			unlock := lockSink(w)
+			errs = append(errs, s.Sync())
+			unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sinks returns the writers the logger outputs to, each once even if it is
+// used for several levels, so that it is not closed twice.
+// It requires l.omu to be held.
+func (l *Logger) sinks() []io.Writer {
+	var ws []io.Writer
+	add := func(w io.Writer) {
+		for _, s := range ws {
+			if sameWriter(s, w) {
+				return
+			}
+		}
+		ws = append(ws, w)
+	}
+	for _, w := range l.writers() {
+		add(w)
+	}
+	if l.errOut != nil {
+		add(l.errorWriter())
+	}
+	for _, level := range Levels() {
+		if o := l.levelOut[level]; o != nil {
+			add(o.Writer())
+		}
+	}
+	return ws
+}
+
+// sameWriter reports whether a and b are the same writer, without
+// panicking on writers of an uncomparable type.
+func sameWriter(a, b io.Writer) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
+
+// AddWriter adds w to the writers of the standard logger, see Logger.AddWriter.
+func AddWriter(w io.Writer) {
+	std.AddWriter(w)
+}
+
+// Writers returns the writers of the standard logger.
+func Writers() []io.Writer {
+	return std.Writers()
+}
+
+// AddWriter makes the logger also write every message to w, besides its
+// current writers. SetWriter replaces the whole set with a single writer.
+// A failing writer does not prevent the others from being written.
+func (l *Logger) AddWriter(w io.Writer) {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	ws := append(l.writers(), w)
+	l.setWriter(&multiWriter{ws: ws})
+}
+
+// Writers returns the writers the logger writes every message to, see AddWriter.
+func (l *Logger) Writers() []io.Writer {
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	return l.writers()
+}
+
+// writers is like Writers but requires l.omu to be held.
+func (l *Logger) writers() []io.Writer {
+	if m, ok := l.writer().(*multiWriter); ok {
+		return append([]io.Writer(nil), m.ws...)
+	}
+	return []io.Writer{l.writer()}
+}
+
+// multiWriter is a writer duplicating its writes to all the writers in ws,
+// in the manner of io.MultiWriter but going on after a failure.
+type multiWriter struct {
+	ws []io.Writer
+}
+
+// Write writes p to every writer, joining the errors.
+func (m *multiWriter) Write(p []byte) (int, error) {
+	var errs []error
+	for _, w := range m.ws {
+		if _, err := writeSink(w, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(p), errors.Join(errs...)
+}
+
+// SyncWriter returns a writer serializing the calls to w, for sinks not safe
+// for concurrent use which are also written directly, the loggers
+// serializing their own calls (see WriteFlushCloser), or to give a sink its
+// own lock instead of the one shared by all of them.
+// The returned writer also serializes and forwards Flush and Close when w
+// provides them, so that it keeps w lifecycle (see WriteFlushCloser).
+func SyncWriter(w io.Writer) io.Writer {
+	return &syncWriter{w: w}
+}
+
+// syncWriter is a writer guarding w with a mutex.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes p to the underlying writer.
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.w.Write(p)
+}
+
+// ConcurrentSafe implements ConcurrentWriter.
+func (s *syncWriter) ConcurrentSafe() {}
+
+// Flush flushes the underlying writer, if it has a Flush() error method.
+func (s *syncWriter) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close closes the underlying writer, if it is a WriteFlushCloser.
+func (s *syncWriter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.w.(WriteFlushCloser); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSink records the lifecycle calls it receives.
+type fakeSink struct {
+	bytes.Buffer
+	flushed, closed bool
+	err             error
+}
+
+func (f *fakeSink) Flush() error {
+	f.flushed = true
+	return f.err
+}
+
+func (f *fakeSink) Close() error {
+	f.closed = true
+	return f.err
+}
+
+var _ WriteFlushCloser = (*fakeSink)(nil)
+
+func TestFlushClose(t *testing.T) {
+	errSink := errors.New("sink failure")
+
+	tt := []struct {
+		name string
+		err  error
+	}{
+		{"success", nil},
+		{"failure", errSink},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &fakeSink{err: tc.err}
+			l := New(LevelInfo)
+			l.SetWriter(s)
+
+			if err := l.Flush(); !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+				t.Errorf("Flush: want %v, got %v", tc.err, err)
+			}
+			if !s.flushed || s.closed {
+				t.Errorf("Flush: want flushed only, got flushed %v closed %v", s.flushed, s.closed)
+			}
+			if err := l.Close(); !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+				t.Errorf("Close: want %v, got %v", tc.err, err)
+			}
+			if !s.closed {
+				t.Error("Close: sink not closed")
+			}
+		})
+	}
+}
+
+func TestFlushPlainWriters(t *testing.T) {
+	out := new(bytes.Buffer)
+	bw := bufio.NewWriter(out)
+	l := New(LevelInfo)
+	l.SetWriter(bw)
+	l.Info("Ciao")
+
+	if out.Len() != 0 {
+		t.Fatalf("output not buffered: %q", out.String())
+	}
+	if err := l.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Len() == 0 {
+		t.Error("Flush did not drive the bufio.Writer")
+	}
+
+	l.SetWriter(new(bytes.Buffer))
+	if err := l.Flush(); err != nil {
+		t.Errorf("Flush on plain writer: unexpected error %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Close on plain writer: unexpected error %v", err)
+	}
+}
+
+// syncSink records the calls to Sync.
+type syncSink struct {
+	bytes.Buffer
+	synced int
+	err    error
+}
+
+func (s *syncSink) Sync() error {
+	s.synced++
+	return s.err
+}
+
+func TestSync(t *testing.T) {
+	errSink := errors.New("sink failure")
+
+	tt := []struct {
+		name string
+		w    func() (w io.Writer, called func() bool)
+		err  error
+	}{
+		{"sync", func() (io.Writer, func() bool) {
+			s := new(syncSink)
+			return s, func() bool { return s.synced == 1 }
+		}, nil},
+		{"sync failure", func() (io.Writer, func() bool) {
+			s := &syncSink{err: errSink}
+			return s, func() bool { return s.synced == 1 }
+		}, errSink},
+		{"flush", func() (io.Writer, func() bool) {
+			s := new(fakeSink)
+			return s, func() bool { return s.flushed && !s.closed }
+		}, nil},
+		{"plain", func() (io.Writer, func() bool) {
+			return new(bytes.Buffer), func() bool { return true }
+		}, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, called := tc.w()
+			l := New(LevelInfo, WithWriter(w))
+			l.Info("Ciao")
+
+			if err := l.Sync(); !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+				t.Errorf("Sync: want %v, got %v", tc.err, err)
+			}
+			if !called() {
+				t.Error("Sync did not drive the writer")
+			}
+		})
+	}
+}
+
+// racyWriter counts the concurrent writes it receives.
+type racyWriter struct {
+	active, overlaps int32
+}
+
+func (r *racyWriter) Write(p []byte) (int, error) {
+	if atomic.AddInt32(&r.active, 1) > 1 {
+		atomic.AddInt32(&r.overlaps, 1)
+	}
+	time.Sleep(10 * time.Microsecond)
+	atomic.AddInt32(&r.active, -1)
+	return len(p), nil
+}
+
+func TestSyncWriter(t *testing.T) {
+	r := new(racyWriter)
+	w := SyncWriter(r)
+	l1, l2 := New(LevelInfo), New(LevelInfo)
+	l1.SetWriter(w)
+	l2.SetWriter(w)
+
+	var wg sync.WaitGroup
+	for _, l := range []*Logger{l1, l2, l1, l2} {
+		wg.Add(1)
+		go func(l *Logger) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.Info("Ciao")
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	if r.overlaps != 0 {
+		t.Errorf("%d concurrent writes through SyncWriter", r.overlaps)
+	}
+}
+
+func TestSinkLocking(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(l *Logger, w io.Writer)
+	}{
+		{"writer", func(l *Logger, w io.Writer) { l.SetWriter(w) }},
+		{"added writer", func(l *Logger, w io.Writer) { l.AddWriter(io.Discard); l.AddWriter(w) }},
+		{"async", func(l *Logger, w io.Writer) { l.SetWriter(w); l.SetAsync(16) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := new(racyWriter)
+			l1, l2 := New(LevelInfo), New(LevelInfo)
+			tt.setup(l1, r)
+			tt.setup(l2, r)
+
+			var wg sync.WaitGroup
+			for _, l := range []*Logger{l1, l2, l1, l2} {
+				wg.Add(1)
+				go func(l *Logger) {
+					defer wg.Done()
+					for i := 0; i < 100; i++ {
+						l.Info("Ciao")
+					}
+				}(l)
+			}
+			wg.Wait()
+			l1.Close() // #nosec
+			l2.Close() // #nosec
+
+			if r.overlaps != 0 {
+				t.Errorf("%d concurrent writes to a sink shared by two loggers", r.overlaps)
+			}
+		})
+	}
+}
+
+// meetingWriter is a ConcurrentWriter whose writes wait for another one to be
+// in progress, counting those waiting in vain.
+type meetingWriter struct {
+	c      chan struct{}
+	missed int32
+}
+
+func (m *meetingWriter) Write(p []byte) (int, error) {
+	select {
+	case m.c <- struct{}{}:
+	case <-m.c:
+	case <-time.After(time.Second):
+		atomic.AddInt32(&m.missed, 1)
+	}
+	return len(p), nil
+}
+
+func (m *meetingWriter) ConcurrentSafe() {}
+
+func TestConcurrentWriter(t *testing.T) {
+	m := &meetingWriter{c: make(chan struct{})}
+	l1, l2 := New(LevelInfo), New(LevelInfo)
+	l1.SetWriter(m)
+	l2.SetWriter(m)
+
+	var wg sync.WaitGroup
+	for _, l := range []*Logger{l1, l2} {
+		wg.Add(1)
+		go func(l *Logger) {
+			defer wg.Done()
+			l.Info("Ciao")
+		}(l)
+	}
+	wg.Wait()
+
+	if m.missed != 0 {
+		t.Error("writes to a ConcurrentWriter serialized")
+	}
+}
+
+func TestSyncWriterLifecycle(t *testing.T) {
+	s := new(fakeSink)
+	l := New(LevelInfo)
+	l.SetWriter(SyncWriter(s))
+
+	if err := l.Flush(); err != nil || !s.flushed {
+		t.Errorf("Flush not forwarded: flushed %v, err %v", s.flushed, err)
+	}
+	if err := l.Close(); err != nil || !s.closed {
+		t.Errorf("Close not forwarded: closed %v, err %v", s.closed, err)
+	}
+
+	out := new(bytes.Buffer)
+	l.SetWriter(SyncWriter(out))
+	if err := l.Flush(); err != nil {
+		t.Errorf("Flush on plain writer: unexpected error %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Close on plain writer: unexpected error %v", err)
+	}
+}
+
+// failWriter fails every write.
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failure")
+}
+
+func TestAddWriter(t *testing.T) {
+	w1, w2 := new(bytes.Buffer), &fakeSink{}
+	l := New(LevelInfo)
+	l.SetWriter(w1)
+	l.AddWriter(failWriter{})
+	l.AddWriter(w2)
+	if got := l.Writers(); len(got) != 3 || got[0] != w1 || got[2] != w2 {
+		t.Fatalf("want [w1 failWriter w2], got %v", got)
+	}
+	l.Info("Ciao")
+
+	for i, w := range []*bytes.Buffer{w1, &w2.Buffer} {
+		pattern := ts + regexp.QuoteMeta(lp[0]+"Ciao") + "\n$"
+		if !regexp.MustCompile(pattern).MatchString(w.String()) {
+			t.Errorf("writer %d: mismatch! Pattern %q, got %q", i, pattern, w.String())
+		}
+	}
+	if err := l.Close(); err != nil || !w2.closed {
+		t.Errorf("Close: want added sink closed, got %v, %v", w2.closed, err)
+	}
+
+	l.SetWriter(w1)
+	if got := l.Writers(); len(got) != 1 || got[0] != w1 {
+		t.Errorf("SetWriter: want [w1], got %v", got)
+	}
+}
+
+func TestCloseSharedSink(t *testing.T) {
+	s, err := NewTempSink(1 << 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(LevelInfo, WithWriter(s), WithErrorWriter(s))
+	l.SetLevelWriter(LevelWarning, s)
+	l.SetLevelWriter(LevelError, s)
+	l.AddWriter(s)
+	l.Error("Ciao")
+
+	for _, f := range []func() error{l.Flush, l.Sync, l.Close} {
+		if err := f(); err != nil {
+			t.Errorf("want no error, got %v", err)
+		}
+	}
+}
+
+// sliceWriter is a writer of an uncomparable type.
+type sliceWriter []string
+
+func (sliceWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestSinksUncomparable(t *testing.T) {
+	l := New(LevelInfo, WithWriter(new(bytes.Buffer)))
+	l.AddWriter(sliceWriter{"a"})
+	l.AddWriter(sliceWriter{"a"})
+
+	l.omu.Lock()
+	defer l.omu.Unlock()
+	if got := len(l.sinks()); got != 3 {
+		t.Errorf("want 3 sinks, got %d", got)
+	}
+}
+
+func TestSyncAsync(t *testing.T) {
+	const n = 10
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async %v", async), func(t *testing.T) {
+			w := &slowWriter{release: make(chan struct{})}
+			l := New(LevelInfo, WithWriter(w))
+			if async {
+				l.SetAsync(n)
+				defer l.Close() // #nosec
+			} else {
+				close(w.release)
+			}
+			for i := 0; i < n; i++ {
+				l.Info(i)
+			}
+			if async {
+				close(w.release)
+			}
+
+			if err := l.Sync(); err != nil {
+				t.Fatalf("Sync: unexpected error %v", err)
+			}
+			if got := strings.Count(w.String(), lp[0]); got != n {
+				t.Errorf("want %d lines written once Sync returns, got %d", n, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler returns a slog.Handler backed by the standard logger,
+// see Logger.SlogHandler.
+func SlogHandler() slog.Handler {
+	return std.SlogHandler()
+}
+
+// SlogHandler returns a slog.Handler writing the records through l, so that
+// l can back log/slog:
+//
+//	slog.SetDefault(slog.New(l.SlogHandler()))
+//
+// Record levels map to the closest Severity at or below them (e.g.
+// slog.LevelWarn to LevelWarning, slog.LevelDebug-4 to LevelTrace), and
+// attributes become fields, keys being qualified by their groups as in
+// "group.key".
+// The caller reported, if any (see SetCaller), is the one of the record, so
+// that it holds for handlers wrapping this one and for helpers setting it.
+func (l *Logger) SlogHandler() slog.Handler {
+	return &slogHandler{l: l}
+}
+
+// slogHandler is the slog.Handler returned by SlogHandler.
+type slogHandler struct {
+	l      *Logger
+	fields []Field // from WithAttrs
+	group  string  // prefix of the keys, from WithGroup
+}
+
+// Enabled reports whether l prints messages of the given level.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.Enabled(severity(level))
+}
+
+// Handle logs r through l.
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := append([]Field(nil), h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, h.group, a)
+		return true
+	})
+
+	l := h.l
+	l.omu.Lock()
+	defer l.unlock()
+
+	// The caller is r.PC, set by slog.Logger or by a wrapper of it. Without
+	// it, Handle is assumed to be called by slog.Logger.log, called by the
+	// method the user called, hence 3 frames from Handle to the user code.
+	l.emit(4, r.PC, severity(r.Level), "", r.Message, fields)
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.fields = append([]Field(nil), h.fields...)
+	for _, a := range attrs {
+		c.fields = appendAttr(c.fields, h.group, a)
+	}
+	return &c
+}
+
+// WithGroup returns a handler qualifying the keys of the following
+// attributes with name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.group = h.group + name + "."
+	return &c
+}
+
+// appendAttr appends a as fields, flattening groups, following the rules of
+// slog.Handler: empty attributes are ignored, as are the keys of groups with
+// an empty key.
+func appendAttr(fields []Field, group string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, group, ga)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: group + a.Key, Value: a.Value.Any()})
+}
+
+// severity maps a slog level to the closest Severity at or below it.
+func severity(level slog.Level) Severity {
+	switch {
+	case level < slog.LevelDebug:
+		return LevelTrace
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarning
+	}
+	return LevelError
+}
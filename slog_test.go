@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	tt := []struct {
+		name string
+		f    func(s *slog.Logger)
+		want string
+	}{
+		{"info", func(s *slog.Logger) { s.Info("Ciao") }, lp[0] + "Ciao"},
+		{"attrs", func(s *slog.Logger) { s.Warn("Ciao", "a", 1, slog.String("b", "x")) }, lp[1] + "Ciao a=1 b=x"},
+		{"error", func(s *slog.Logger) { s.Error("Ciao", "err", "boom") }, lp[2] + "Ciao err=boom"},
+		{"debug", func(s *slog.Logger) { s.Debug("Ciao") }, dp + "Ciao"},
+		{"trace", func(s *slog.Logger) { s.Log(context.Background(), slog.LevelDebug-4, "Ciao") }, tp + "Ciao"},
+		{"with attrs", func(s *slog.Logger) { s.With("a", 1).Info("Ciao", "b", 2) }, lp[0] + "Ciao a=1 b=2"},
+		{"with group", func(s *slog.Logger) { s.With("a", 1).WithGroup("g").With("b", 2).Info("Ciao", "c", 3) }, lp[0] + "Ciao a=1 g.b=2 g.c=3"},
+		{"nested group", func(s *slog.Logger) { s.WithGroup("g").Info("Ciao", slog.Group("h", "a", 1, "b", 2)) }, lp[0] + "Ciao g.h.a=1 g.h.b=2"},
+		{"inline group", func(s *slog.Logger) { s.Info("Ciao", slog.Group("", "a", 1)) }, lp[0] + "Ciao a=1"},
+		{"empty attr", func(s *slog.Logger) { s.Info("Ciao", slog.Attr{}) }, lp[0] + "Ciao"},
+		{"empty group", func(s *slog.Logger) { s.WithGroup("").Info("Ciao", "a", 1) }, lp[0] + "Ciao a=1"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(LevelTrace)
+			l.SetWriter(w)
+			tc.f(slog.New(l.SlogHandler()))
+
+			pattern := ts + regexp.QuoteMeta(tc.want) + "\n$"
+			if !regexp.MustCompile(pattern).MatchString(w.String()) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+			}
+		})
+	}
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelWarning)
+	l.SetWriter(w)
+	s := slog.New(l.SlogHandler())
+
+	s.Info("Ciao")
+	if w.Len() > 0 {
+		t.Fatalf("want no output, got %q", w.String())
+	}
+	if s.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Enabled(LevelInfo): want false, got true")
+	}
+	l.SetLevel(LevelInfo)
+	if !s.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Enabled(LevelInfo) after SetLevel: want true, got false")
+	}
+}
+
+func TestSlogHandlerCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo)
+	l.SetWriter(w)
+	l.SetCallerMinLevel(LevelInfo)
+	s := slog.New(l.SlogHandler())
+	s.Info("Ciao")
+	s.LogAttrs(context.Background(), slog.LevelInfo, "Ciao")
+
+	pattern := ts + "slog_test.go:[0-9]+: " + regexp.QuoteMeta(lp[0]+"Ciao") + "\n"
+	if !regexp.MustCompile(pattern + pattern[1:] + "$").MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q twice, got %q", pattern, w.String())
+	}
+}
+
+// wrapHandler is a handler wrapping another one, adding frames to Handle.
+type wrapHandler struct{ slog.Handler }
+
+func (h wrapHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.Handler.Handle(ctx, r)
+}
+
+// infoAttrs is a helper logging through s, reporting its own caller in the
+// manner recommended by log/slog.
+func infoAttrs(s *slog.Logger, msg string) {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:]) // skip Callers and infoAttrs
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, msg, pcs[0])
+	s.Handler().Handle(context.Background(), r) // #nosec
+}
+
+func TestSlogHandlerRecordPC(t *testing.T) {
+	w := new(bytes.Buffer)
+	l := New(LevelInfo, WithWriter(w))
+	l.SetTimestamp(false)
+	l.Verbose(true)
+	s := slog.New(wrapHandler{l.SlogHandler()})
+	_, _, line, _ := runtime.Caller(0)
+	s.Info("Ciao")
+	infoAttrs(s, "Ciao")
+	l.SetFormatter(JSONFormatter{})
+	s.Info("Ciao")
+
+	want := "slog_test.go:" + strconv.Itoa(line+1) + ": " + lp[0] + "Ciao\n" +
+		"slog_test.go:" + strconv.Itoa(line+2) + ": " + lp[0] + "Ciao\n" +
+		`{"level":"info","msg":"Ciao","caller":"slog_test.go:` + strconv.Itoa(line+4) + `","func":"log.TestSlogHandlerRecordPC"}` + "\n"
+	if w.String() != want {
+		t.Errorf("mismatch! Want %q, got %q", want, w.String())
+	}
+}
@@ -0,0 +1,80 @@
+//go:build !windows && !plan9
+
+package log
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// NewSyslogWriter returns a writer sending every write to the syslog daemon
+// at addr on network (see syslog.Dial), tagged with tag, at the LOG_INFO
+// priority. Use SetSyslog for per message priorities.
+func NewSyslogWriter(network, addr, tag string) (io.Writer, error) {
+	return syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+}
+
+// SyslogPriority returns the syslog priority matching level: LOG_DEBUG for
+// Debug and Trace, LOG_INFO, LOG_WARNING and LOG_ERR.
+func SyslogPriority(level Severity) syslog.Priority {
+	switch {
+	case level < LevelInfo:
+		return syslog.LOG_DEBUG
+	case level < LevelWarning:
+		return syslog.LOG_INFO
+	case level < LevelError:
+		return syslog.LOG_WARNING
+	}
+	return syslog.LOG_ERR
+}
+
+// SetSyslog makes the standard logger also send its messages to syslog,
+// see Logger.SetSyslog.
+func SetSyslog(network, addr, tag string) error {
+	return std.SetSyslog(network, addr, tag)
+}
+
+// SetSyslog makes the logger also send every message to the syslog daemon
+// at addr on network (see syslog.Dial), tagged with tag, at the priority of
+// its level (see SyslogPriority). Syslog adds its own timestamp, so the
+// messages are sent without timestamp nor level label.
+// The connection is closed by Close, or by a following SetSyslog.
+func (l *Logger) SetSyslog(network, addr, tag string) error {
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return err
+	}
+
+	l.omu.Lock()
+	defer l.omu.Unlock()
+
+	var cerr error
+	if l.sys != nil {
+		cerr = l.sys.Close()
+	}
+	l.sys = &syslogWriter{w: w}
+	return cerr
+}
+
+// syslogWriter sends messages to syslog with the priority of their level.
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+// WriteLevel sends msg with the priority of level.
+func (s *syslogWriter) WriteLevel(level Severity, msg string) error {
+	switch SyslogPriority(level) {
+	case syslog.LOG_DEBUG:
+		return s.w.Debug(msg)
+	case syslog.LOG_INFO:
+		return s.w.Info(msg)
+	case syslog.LOG_WARNING:
+		return s.w.Warning(msg)
+	}
+	return s.w.Err(msg)
+}
+
+// Close closes the connection to syslog.
+func (s *syslogWriter) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,91 @@
+//go:build !windows && !plan9
+
+package log
+
+import (
+	"log/syslog"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listenSyslog returns a local UDP listener and a function reading the next
+// syslog packet.
+func listenSyslog(t *testing.T) (string, func(t *testing.T) string) {
+	t.Helper()
+	c, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("no UDP listener: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c.LocalAddr().String(), func(t *testing.T) string {
+		t.Helper()
+		buf := make([]byte, 1024)
+		c.SetReadDeadline(time.Now().Add(5 * time.Second)) // #nosec
+		n, _, err := c.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("no syslog packet: %v", err)
+		}
+		return string(buf[:n])
+	}
+}
+
+func TestSetSyslog(t *testing.T) {
+	addr, read := listenSyslog(t)
+	l := New(LevelDebug)
+	l.SetWriter(new(strings.Builder))
+	if err := l.SetSyslog("udp", addr, "app"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer l.Close()
+
+	tt := []struct {
+		name string
+		f    func()
+		want string // priority and message
+	}{
+		{"debug", func() { l.Debug("Ciao") }, "<15>.* app\\[[0-9]+\\]: Ciao\n?$"},
+		{"info", func() { l.Info("Ciao") }, "<14>.* app\\[[0-9]+\\]: Ciao\n?$"},
+		{"warning", func() { l.Warning("Ciao") }, "<12>.* app\\[[0-9]+\\]: Ciao\n?$"},
+		{"error", func() { l.Error("Ciao") }, "<11>.* app\\[[0-9]+\\]: Ciao\n?$"},
+		{"fields", func() { l.With("a", 1).Info("Ciao") }, "<14>.* app\\[[0-9]+\\]: Ciao a=1\n?$"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.f()
+			pattern := "^" + tc.want
+			if got := read(t); !regexp.MustCompile(pattern).MatchString(got) {
+				t.Errorf("mismatch! Pattern %q, got %q", pattern, got)
+			}
+		})
+	}
+}
+
+func TestNewSyslogWriter(t *testing.T) {
+	addr, read := listenSyslog(t)
+	w, err := NewSyslogWriter("udp", addr, "app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := w.Write([]byte("Ciao\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pattern := "^<14>.* app\\[[0-9]+\\]: Ciao\n$"
+	if got := read(t); !regexp.MustCompile(pattern).MatchString(got) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, got)
+	}
+}
+
+func TestSyslogPriority(t *testing.T) {
+	want := []syslog.Priority{syslog.LOG_DEBUG, syslog.LOG_DEBUG, syslog.LOG_INFO, syslog.LOG_WARNING, syslog.LOG_ERR}
+	for i, level := range Levels() {
+		if got := SyslogPriority(level); got != want[i] {
+			t.Errorf("%v: want %d, got %d", level, want[i], got)
+		}
+	}
+}
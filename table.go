@@ -0,0 +1,37 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Table logs rows as an aligned two-column table sorted by key, one line per row.
+func Table(level Severity, rows map[string]string) {
+	std.Table(level, rows)
+}
+
+// Table logs rows as an aligned two-column table sorted by key, one line per row.
+// The lines are written as a contiguous block, never interleaved with other
+// messages of the logger and of the loggers derived from it (see With).
+func (l *Logger) Table(level Severity, rows map[string]string) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	keys := make([]string, 0, len(rows))
+	width := 0
+	for k := range rows {
+		keys = append(keys, k)
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	sort.Strings(keys)
+
+	l.omu.Lock()
+	defer l.unlock()
+
+	for _, k := range keys {
+		l.emit(l.calldepth, 0, level, "", fmt.Sprintf("%-*s  %s", width, k, rows[k]), nil)
+	}
+}
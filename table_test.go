@@ -0,0 +1,64 @@
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	rows := map[string]string{"port": "8080", "host": "localhost", "tls": "off"}
+
+	tt := []struct {
+		name  string
+		level Severity
+		min   Severity
+		want  []string
+	}{
+		{"info", LevelInfo, LevelInfo, []string{lp[0] + "host  localhost", lp[0] + "port  8080", lp[0] + "tls   off"}},
+		{"warning", LevelWarning, LevelWarning, []string{lp[1] + "host  localhost", lp[1] + "port  8080", lp[1] + "tls   off"}},
+		{"below level", LevelInfo, LevelWarning, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			l := New(tc.min)
+			l.SetWriter(w)
+			l.Table(tc.level, rows)
+
+			lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+			if tc.want == nil {
+				if w.Len() != 0 {
+					t.Fatalf("want no output, got %q", w.String())
+				}
+				return
+			}
+			if len(lines) != len(tc.want) {
+				t.Fatalf("want %d lines, got %q", len(tc.want), lines)
+			}
+			for i, line := range lines {
+				pattern := ts + regexp.QuoteMeta(tc.want[i]) + "$"
+				if !regexp.MustCompile(pattern).MatchString(line) {
+					t.Errorf("mismatch! Pattern %q, got %q", pattern, line)
+				}
+			}
+		})
+	}
+}
+
+func TestTableCaller(t *testing.T) {
+	w := new(bytes.Buffer)
+	SetWriter(w)
+	SetLevel(LevelInfo)
+	SetCallerMinLevel(LevelInfo)
+	defer SetCallerMinLevel(LevelOff)
+	f := func() { Table(LevelInfo, map[string]string{"k": "v"}) }
+	f()
+
+	pattern := ts + regexp.QuoteMeta(callerOf(f)+lp[0]+"k  v") + "\n$"
+	if !regexp.MustCompile(pattern).MatchString(w.String()) {
+		t.Errorf("mismatch! Pattern %q, got %q", pattern, w.String())
+	}
+}
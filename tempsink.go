@@ -0,0 +1,101 @@
+package log
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// ErrSinkFull is returned by TempSink.Write once the size bound is reached.
+var ErrSinkFull = errors.New("log: temp sink full")
+
+// TempSink is a writer backed by a temporary file which is deleted on Close.
+// It is meant for ephemeral debug captures: set it as the logger's writer,
+// read the captured output back with Contents, then Close it to clean up.
+type TempSink struct {
+	mu   sync.Mutex
+	f    *os.File
+	name string
+	max  int
+	n    int
+}
+
+// NewTempSink creates a TempSink writing to a new temporary file.
+// maxBytes bounds the file size, writes beyond the bound are dropped.
+// A maxBytes less than or equal to zero means no bound.
+func NewTempSink(maxBytes int) (*TempSink, error) {
+	f, err := os.CreateTemp("", "log-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &TempSink{f: f, name: f.Name(), max: maxBytes}, nil
+}
+
+// Write appends p to the temporary file.
+// If p does not entirely fit within the size bound, only the part that fits
+// is written and ErrSinkFull is returned.
+func (s *TempSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return 0, os.ErrClosed
+	}
+	var full bool
+	if s.max > 0 && s.n+len(p) > s.max {
+		p = p[:s.max-s.n]
+		full = true
+	}
+	n, err := s.f.Write(p)
+	s.n += n
+	if err == nil && full {
+		err = ErrSinkFull
+	}
+	return n, err
+}
+
+// ConcurrentSafe implements ConcurrentWriter.
+func (s *TempSink) ConcurrentSafe() {}
+
+// Name returns the path of the temporary file.
+func (s *TempSink) Name() string {
+	return s.name
+}
+
+// Contents returns everything written to the sink so far.
+func (s *TempSink) Contents() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return nil, os.ErrClosed
+	}
+	return os.ReadFile(s.name)
+}
+
+// Flush commits the temporary file contents to stable storage.
+func (s *TempSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	return s.f.Sync()
+}
+
+// Close closes and removes the temporary file.
+func (s *TempSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	err := s.f.Close()
+	s.f = nil
+	if rerr := os.Remove(s.name); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestTempSink(t *testing.T) {
+	s, err := NewTempSink(0)
+	if err != nil {
+		t.Fatalf("unable to create temp sink: %v", err)
+	}
+	l := New(LevelInfo)
+	l.SetWriter(s)
+	l.Info("Ciao")
+
+	got, err := s.Contents()
+	if err != nil {
+		t.Fatalf("unable to read contents: %v", err)
+	}
+	pattern := ts + lp[0] + "Ciao\n$"
+	if !regexp.MustCompile(pattern).Match(got) {
+		t.Fatalf("mismatch! Pattern %q, got %q", pattern, got)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("unable to close: %v", err)
+	}
+	if _, err := os.Stat(s.Name()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("temp file %q still present after Close: %v", s.Name(), err)
+	}
+	if _, err := s.Contents(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Contents after Close: want os.ErrClosed, got %v", err)
+	}
+}
+
+func TestTempSinkBound(t *testing.T) {
+	s, err := NewTempSink(8)
+	if err != nil {
+		t.Fatalf("unable to create temp sink: %v", err)
+	}
+	defer s.Close()
+
+	if n, err := s.Write([]byte("Ciao")); n != 4 || err != nil {
+		t.Fatalf("first write: want 4, nil, got %d, %v", n, err)
+	}
+	if n, err := s.Write([]byte("ciao ciao")); n != 4 || !errors.Is(err, ErrSinkFull) {
+		t.Fatalf("second write: want 4, ErrSinkFull, got %d, %v", n, err)
+	}
+	if n, err := s.Write([]byte("x")); n != 0 || !errors.Is(err, ErrSinkFull) {
+		t.Fatalf("write when full: want 0, ErrSinkFull, got %d, %v", n, err)
+	}
+
+	got, _ := s.Contents()
+	if string(got) != "Ciaociao" {
+		t.Errorf("want %q, got %q", "Ciaociao", got)
+	}
+}
+
+func TestTempSinkLifecycle(t *testing.T) {
+	s, err := NewTempSink(0)
+	if err != nil {
+		t.Fatalf("unable to create temp sink: %v", err)
+	}
+	l := New(LevelInfo)
+	l.SetWriter(s)
+	l.Info("Ciao")
+
+	if err := l.Flush(); err != nil {
+		t.Fatalf("unexpected error on Flush: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error on Close: %v", err)
+	}
+	if _, err := os.Stat(s.Name()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("temp file %q still present after logger Close: %v", s.Name(), err)
+	}
+}
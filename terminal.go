@@ -0,0 +1,16 @@
+package log
+
+import "os"
+
+// IsTerminal reports whether the standard logger writes to a terminal.
+func IsTerminal() bool {
+	return std.IsTerminal()
+}
+
+// IsTerminal reports whether the logger writer is a terminal, e.g. to decide
+// whether to draw interactive output such as progress bars alongside logs.
+// It is false for anything but an *os.File, and for files and pipes.
+func (l *Logger) IsTerminal() bool {
+	f, ok := l.Writer().(*os.File)
+	return ok && isTerminal(f)
+}
@@ -0,0 +1,8 @@
+//go:build darwin || freebsd || netbsd || openbsd || dragonfly
+
+package log
+
+import "syscall"
+
+// ioctlReadTermios is the ioctl request reading terminal attributes.
+const ioctlReadTermios = syscall.TIOCGETA
@@ -0,0 +1,6 @@
+package log
+
+import "syscall"
+
+// ioctlReadTermios is the ioctl request reading terminal attributes.
+const ioctlReadTermios = syscall.TCGETS
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer pw.Close()
+
+	tt := []struct {
+		name string
+		w    io.Writer
+	}{
+		{"buffer", new(bytes.Buffer)},
+		{"file", f},
+		{"pipe", pw},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New(LevelInfo)
+			l.SetWriter(tc.w)
+			if l.IsTerminal() {
+				t.Error("want false, got true")
+			}
+		})
+	}
+}
+
+func TestIsTerminalTTY(t *testing.T) {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("no terminal available: %v", err)
+	}
+	defer tty.Close()
+
+	l := New(LevelInfo)
+	l.SetWriter(tty)
+	if !l.IsTerminal() {
+		t.Error("want true, got false")
+	}
+}